@@ -0,0 +1,158 @@
+// Package bimap implements a bidirectional map, where both keys and values are unique.
+package bimap
+
+import (
+	"fmt"
+
+	"github.com/igorroncevic/go-utils/hashmap"
+	"github.com/igorroncevic/go-utils/util"
+)
+
+var (
+	ErrValueExists = fmt.Errorf("value is already mapped to a different key")
+)
+
+// BiMap is a map that preserves the uniqueness of its values as well as that of its keys,
+// which allows looking keys up by their value.
+type BiMap[K, V any] struct {
+	forward  *hashmap.Map[K, V]
+	backward *hashmap.Map[V, K]
+
+	ops ops[K, V]
+}
+
+type ops[K, V any] struct {
+	keyEquals util.EqualsFn[K]
+	keyHash   util.HashFn[K]
+	valEquals util.EqualsFn[V]
+	valHash   util.HashFn[V]
+}
+
+// New constructs a new bimap with the given capacity.
+func New[K, V any](
+	capacity uint64,
+	keyEquals util.EqualsFn[K], keyHash util.HashFn[K],
+	valEquals util.EqualsFn[V], valHash util.HashFn[V],
+) *BiMap[K, V] {
+	return &BiMap[K, V]{
+		forward:  hashmap.New[K, V](capacity, keyEquals, keyHash),
+		backward: hashmap.New[V, K](capacity, valEquals, valHash),
+		ops: ops[K, V]{
+			keyEquals: keyEquals,
+			keyHash:   keyHash,
+			valEquals: valEquals,
+			valHash:   valHash,
+		},
+	}
+}
+
+// Put maps the given key to the given value. If the key already exists its
+// value will be overwritten with the new value. If the value is already mapped to
+// a different key, ErrValueExists is returned and the bimap is left unchanged.
+func (b *BiMap[K, V]) Put(key K, val V) error {
+	if existing, ok := b.backward.Get(val); ok && !b.ops.keyEquals(existing, key) {
+		return ErrValueExists
+	}
+
+	b.put(key, val)
+
+	return nil
+}
+
+// ForcePut maps the given key to the given value, removing any other key that was
+// previously mapped to this value.
+func (b *BiMap[K, V]) ForcePut(key K, val V) {
+	if existing, ok := b.backward.Get(val); ok {
+		b.forward.Remove(existing)
+		b.backward.Remove(val)
+	}
+
+	b.put(key, val)
+}
+
+// Get returns the value stored for this key, or false if there is no such value.
+func (b *BiMap[K, V]) Get(key K) (V, bool) {
+	return b.forward.Get(key)
+}
+
+// GetKey returns the key mapped to this value, or false if there is no such key.
+func (b *BiMap[K, V]) GetKey(val V) (K, bool) {
+	return b.backward.Get(val)
+}
+
+// ContainsKey returns whether the key is present in the bimap.
+func (b *BiMap[K, V]) ContainsKey(key K) bool {
+	_, ok := b.forward.Get(key)
+	return ok
+}
+
+// ContainsValue returns whether the value is present in the bimap.
+func (b *BiMap[K, V]) ContainsValue(val V) bool {
+	_, ok := b.backward.Get(val)
+	return ok
+}
+
+// Remove removes the specified key together with its value.
+func (b *BiMap[K, V]) Remove(key K) {
+	val, ok := b.forward.Get(key)
+	if !ok {
+		return
+	}
+
+	b.forward.Remove(key)
+	b.backward.Remove(val)
+}
+
+// RemoveValue removes the specified value together with its key.
+func (b *BiMap[K, V]) RemoveValue(val V) {
+	b.Inverse().Remove(val)
+}
+
+// Inverse returns a view of this bimap with keys and values swapped.
+// Both of them share the same underlying data, so changes to one are visible in the other.
+func (b *BiMap[K, V]) Inverse() *BiMap[V, K] {
+	return &BiMap[V, K]{
+		forward:  b.backward,
+		backward: b.forward,
+		ops: ops[V, K]{
+			keyEquals: b.ops.valEquals,
+			keyHash:   b.ops.valHash,
+			valEquals: b.ops.keyEquals,
+			valHash:   b.ops.keyHash,
+		},
+	}
+}
+
+// Clear removes all key-value pairs from the bimap.
+func (b *BiMap[K, V]) Clear() {
+	b.forward.Clear()
+	b.backward.Clear()
+}
+
+// Size returns the number of key-value pairs in the bimap.
+func (b *BiMap[K, V]) Size() int {
+	return b.forward.Size()
+}
+
+// Each calls 'fn' on every key-value pair in the bimap in no particular order.
+func (b *BiMap[K, V]) Each(fn func(key K, val V)) {
+	b.forward.Each(fn)
+}
+
+// Copy returns a copy of this bimap, which shares the copy-on-write semantics of hashmap.Map.
+func (b *BiMap[K, V]) Copy() *BiMap[K, V] {
+	return &BiMap[K, V]{
+		forward:  b.forward.Copy(),
+		backward: b.backward.Copy(),
+		ops:      b.ops,
+	}
+}
+
+func (b *BiMap[K, V]) put(key K, val V) {
+	if old, ok := b.forward.Get(key); ok {
+		b.backward.Remove(old)
+	}
+
+	b.forward.Put(key, val)
+	b.backward.Put(val, key)
+}
@@ -0,0 +1,58 @@
+package bimap_test
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/igorroncevic/go-utils/bimap"
+	"github.com/igorroncevic/go-utils/util"
+)
+
+func TestBiMap(t *testing.T) {
+	b := bimap.New[int, string](1, util.Equals[int], util.HashInt, util.Equals[string], util.HashString)
+
+	assert.NoError(t, b.Put(1, "foo"))
+	assert.NoError(t, b.Put(2, "bar"))
+
+	val, ok := b.Get(1)
+	assert.True(t, ok)
+	assert.Equal(t, "foo", val)
+
+	key, ok := b.GetKey("bar")
+	assert.True(t, ok)
+	assert.Equal(t, 2, key)
+
+	// Values must be unique
+	assert.Equal(t, bimap.ErrValueExists, b.Put(3, "foo"))
+	assert.False(t, b.ContainsKey(3))
+
+	// Overwriting a key releases its old value
+	assert.NoError(t, b.Put(1, "baz"))
+	assert.False(t, b.ContainsValue("foo"))
+	assert.Equal(t, 2, b.Size(), "unexpected bimap size")
+
+	// Force put evicts the key that held the value
+	b.ForcePut(3, "bar")
+	assert.False(t, b.ContainsKey(2))
+
+	key, _ = b.GetKey("bar")
+	assert.Equal(t, 3, key)
+	assert.Equal(t, 2, b.Size(), "unexpected bimap size after force put")
+
+	// Inverse shares the data
+	inverse := b.Inverse()
+	assert.NoError(t, inverse.Put("qux", 4))
+
+	val, _ = b.Get(4)
+	assert.Equal(t, "qux", val)
+
+	b.RemoveValue("qux")
+	assert.False(t, inverse.ContainsKey("qux"))
+
+	// Copies are independent
+	cpy := b.Copy()
+	cpy.Clear()
+	assert.Equal(t, 0, cpy.Size(), "unexpected size after clear")
+	assert.Equal(t, 2, b.Size(), "original bimap was affected by clear of the copy")
+	assert.True(t, b.ContainsValue("baz"))
+}
@@ -0,0 +1,261 @@
+// Package bitset implements a dense set of non-negative integers, stored as one bit per integer.
+package bitset
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+
+	"github.com/igorroncevic/go-utils/util"
+)
+
+const wordSize = 64
+
+var (
+	ErrInvalidData = fmt.Errorf("invalid bitset data")
+)
+
+// BitSet is a set of non-negative integers, which grows as needed to fit the largest one.
+// Set operations are done a whole 64-bit word at a time.
+type BitSet struct {
+	words []uint64
+}
+
+// New constructs a new bitset with room for integers up to 'size' without having to grow.
+func New(size uint) *BitSet {
+	return &BitSet{
+		words: make([]uint64, wordsNeeded(size)),
+	}
+}
+
+// Set adds 'i' to the set.
+func (b *BitSet) Set(i uint) {
+	b.grow(i)
+	b.words[i/wordSize] |= 1 << (i % wordSize)
+}
+
+// Clear removes 'i' from the set.
+func (b *BitSet) Clear(i uint) {
+	if i/wordSize < uint(len(b.words)) {
+		b.words[i/wordSize] &^= 1 << (i % wordSize)
+	}
+}
+
+// Test returns whether 'i' is in the set.
+func (b *BitSet) Test(i uint) bool {
+	if i/wordSize >= uint(len(b.words)) {
+		return false
+	}
+
+	return b.words[i/wordSize]&(1<<(i%wordSize)) != 0
+}
+
+// Flip adds 'i' to the set if it is not in it, and removes it otherwise.
+func (b *BitSet) Flip(i uint) {
+	b.grow(i)
+	b.words[i/wordSize] ^= 1 << (i % wordSize)
+}
+
+// Count returns the number of integers in the set.
+func (b *BitSet) Count() int {
+	var count int
+
+	for _, word := range b.words {
+		count += bits.OnesCount64(word)
+	}
+
+	return count
+}
+
+// ClearAll removes all integers from the set.
+func (b *BitSet) ClearAll() {
+	for i := range b.words {
+		b.words[i] = 0
+	}
+}
+
+// NextSet returns the smallest integer in the set that is greater than or equal to 'i',
+// or false if there is none.
+func (b *BitSet) NextSet(i uint) (uint, bool) {
+	idx := i / wordSize
+	if idx >= uint(len(b.words)) {
+		return 0, false
+	}
+
+	// Ignore the bits below 'i' in its own word
+	word := b.words[idx] >> (i % wordSize)
+	if word != 0 {
+		return i + uint(bits.TrailingZeros64(word)), true
+	}
+
+	for idx++; idx < uint(len(b.words)); idx++ {
+		if b.words[idx] != 0 {
+			return idx*wordSize + uint(bits.TrailingZeros64(b.words[idx])), true
+		}
+	}
+
+	return 0, false
+}
+
+// NextClear returns the smallest integer not in the set that is greater than or equal to 'i'.
+// Since the set is finite, there always is one.
+func (b *BitSet) NextClear(i uint) uint {
+	idx := i / wordSize
+	if idx >= uint(len(b.words)) {
+		return i
+	}
+
+	// Negate before shifting, so that the bits shifted in at the top are not mistaken for clear ones
+	word := ^b.words[idx] >> (i % wordSize)
+	if word != 0 {
+		return i + uint(bits.TrailingZeros64(word))
+	}
+
+	for idx++; idx < uint(len(b.words)); idx++ {
+		if b.words[idx] != ^uint64(0) {
+			return idx*wordSize + uint(bits.TrailingZeros64(^b.words[idx]))
+		}
+	}
+
+	return uint(len(b.words)) * wordSize
+}
+
+// Each calls 'fn' on every integer in the set in ascending order.
+// Iteration stops as soon as 'fn' returns true.
+func (b *BitSet) Each(fn func(i uint) bool) {
+	for i, ok := b.NextSet(0); ok; i, ok = b.NextSet(i + 1) {
+		if shouldStop := fn(i); shouldStop {
+			return
+		}
+	}
+}
+
+// Union returns a new set with the integers that are in either of the sets.
+func (b *BitSet) Union(other *BitSet) *BitSet {
+	longer, shorter := b, other
+	if len(shorter.words) > len(longer.words) {
+		longer, shorter = shorter, longer
+	}
+
+	result := longer.Copy()
+	for i, word := range shorter.words {
+		result.words[i] |= word
+	}
+
+	return result
+}
+
+// Intersect returns a new set with the integers that are in both of the sets.
+func (b *BitSet) Intersect(other *BitSet) *BitSet {
+	result := &BitSet{
+		words: make([]uint64, minLen(b.words, other.words)),
+	}
+
+	for i := range result.words {
+		result.words[i] = b.words[i] & other.words[i]
+	}
+
+	return result
+}
+
+// Difference returns a new set with the integers of this set that are not in 'other'.
+func (b *BitSet) Difference(other *BitSet) *BitSet {
+	result := b.Copy()
+
+	for i := 0; i < minLen(b.words, other.words); i++ {
+		result.words[i] &^= other.words[i]
+	}
+
+	return result
+}
+
+// Equal returns whether both sets contain the same integers.
+func (b *BitSet) Equal(other *BitSet) bool {
+	longer, shorter := b, other
+	if len(shorter.words) > len(longer.words) {
+		longer, shorter = shorter, longer
+	}
+
+	for i, word := range longer.words {
+		if i < len(shorter.words) && shorter.words[i] != word || i >= len(shorter.words) && word != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Copy returns a copy of this set.
+func (b *BitSet) Copy() *BitSet {
+	words := make([]uint64, len(b.words))
+	copy(words, b.words)
+
+	return &BitSet{words}
+}
+
+// MarshalBinary encodes the set as its little-endian 64-bit words, without trailing empty words,
+// so that equal sets always have the same encoding.
+func (b *BitSet) MarshalBinary() ([]byte, error) {
+	used := len(b.words)
+	for used > 0 && b.words[used-1] == 0 {
+		used--
+	}
+
+	data := make([]byte, used*8)
+	for i := 0; i < used; i++ {
+		binary.LittleEndian.PutUint64(data[i*8:], b.words[i])
+	}
+
+	return data, nil
+}
+
+// UnmarshalBinary replaces the contents of the set with the ones encoded by MarshalBinary.
+func (b *BitSet) UnmarshalBinary(data []byte) error {
+	if len(data)%8 != 0 {
+		return ErrInvalidData
+	}
+
+	b.words = make([]uint64, len(data)/8)
+	for i := range b.words {
+		b.words[i] = binary.LittleEndian.Uint64(data[i*8:])
+	}
+
+	return nil
+}
+
+// grow makes sure that 'i' fits into the set.
+func (b *BitSet) grow(i uint) {
+	needed := int(i/wordSize) + 1
+	if needed <= len(b.words) {
+		return
+	}
+
+	if needed <= cap(b.words) {
+		// Words past the length may hold stale bits, so clear them before they become part of the set
+		old := len(b.words)
+		b.words = b.words[:needed]
+
+		for idx := old; idx < needed; idx++ {
+			b.words[idx] = 0
+		}
+
+		return
+	}
+
+	// Grow to at least twice the capacity, to keep setting ascending integers amortised O(1)
+	words := make([]uint64, needed, util.Max(needed, 2*cap(b.words)))
+	copy(words, b.words)
+	b.words = words
+}
+
+func wordsNeeded(size uint) int {
+	return int((size + wordSize - 1) / wordSize)
+}
+
+func minLen(a, b []uint64) int {
+	if len(a) < len(b) {
+		return len(a)
+	}
+
+	return len(b)
+}
@@ -0,0 +1,147 @@
+package bitset_test
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/igorroncevic/go-utils/bitset"
+)
+
+func fromValues(values ...uint) *bitset.BitSet {
+	b := bitset.New(0)
+	for _, val := range values {
+		b.Set(val)
+	}
+
+	return b
+}
+
+func toSlice(b *bitset.BitSet) []uint {
+	values := []uint{}
+
+	b.Each(func(i uint) bool {
+		values = append(values, i)
+		return false
+	})
+
+	return values
+}
+
+func TestBitSet(t *testing.T) {
+	b := bitset.New(10)
+
+	b.Set(1)
+	b.Set(63)
+	b.Set(64)
+	b.Set(1000)
+
+	assert.True(t, b.Test(1))
+	assert.True(t, b.Test(64))
+	assert.True(t, b.Test(1000))
+	assert.False(t, b.Test(2))
+	assert.False(t, b.Test(100000))
+	assert.Equal(t, 4, b.Count())
+
+	b.Clear(63)
+	b.Clear(100000)
+	assert.False(t, b.Test(63))
+
+	b.Flip(1)
+	b.Flip(2)
+	assert.False(t, b.Test(1))
+	assert.True(t, b.Test(2))
+
+	assert.Equal(t, []uint{2, 64, 1000}, toSlice(b))
+
+	b.ClearAll()
+	assert.Equal(t, 0, b.Count())
+}
+
+func TestBitSetNext(t *testing.T) {
+	b := fromValues(0, 1, 2, 3, 70, 200)
+
+	next, ok := b.NextSet(4)
+	assert.True(t, ok)
+	assert.Equal(t, uint(70), next)
+
+	next, ok = b.NextSet(70)
+	assert.True(t, ok)
+	assert.Equal(t, uint(70), next)
+
+	_, ok = b.NextSet(201)
+	assert.False(t, ok)
+
+	assert.Equal(t, uint(4), b.NextClear(0))
+	assert.Equal(t, uint(71), b.NextClear(70))
+	assert.Equal(t, uint(5000), b.NextClear(5000))
+
+	full := bitset.New(128)
+	for i := uint(0); i < 128; i++ {
+		full.Set(i)
+	}
+
+	assert.Equal(t, uint(128), full.NextClear(3))
+}
+
+func TestBitSetAlgebra(t *testing.T) {
+	a := fromValues(1, 2, 3, 100)
+	b := fromValues(2, 3, 4, 300)
+
+	assert.Equal(t, []uint{1, 2, 3, 4, 100, 300}, toSlice(a.Union(b)))
+	assert.Equal(t, []uint{2, 3}, toSlice(a.Intersect(b)))
+	assert.Equal(t, []uint{1, 100}, toSlice(a.Difference(b)))
+	assert.Equal(t, []uint{4, 300}, toSlice(b.Difference(a)))
+
+	// Operands are not modified
+	assert.Equal(t, []uint{1, 2, 3, 100}, toSlice(a))
+
+	assert.True(t, fromValues(1, 2).Equal(bitset.New(1000).Union(fromValues(1, 2))))
+	assert.False(t, a.Equal(b))
+}
+
+func TestBitSetBinary(t *testing.T) {
+	a := fromValues(1, 64, 129)
+
+	// Trailing empty words are not encoded
+	bigger := bitset.New(10000)
+	bigger.Set(1)
+	bigger.Set(64)
+	bigger.Set(129)
+
+	data, err := a.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, 24, len(data))
+
+	biggerData, err := bigger.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, data, biggerData)
+
+	decoded := bitset.New(0)
+	assert.NoError(t, decoded.UnmarshalBinary(data))
+	assert.True(t, a.Equal(decoded))
+
+	assert.Equal(t, bitset.ErrInvalidData, decoded.UnmarshalBinary([]byte{1, 2, 3}))
+}
+
+func TestBitSetAscendingAllocations(t *testing.T) {
+	allocs := testing.AllocsPerRun(1, func() {
+		b := bitset.New(0)
+		for i := uint(0); i < 1<<20; i++ {
+			b.Set(i)
+		}
+	})
+
+	// Growing geometrically takes a logarithmic number of allocations
+	assert.True(t, allocs < 30, "%v allocations", allocs)
+}
+
+func BenchmarkBitSetAscending(b *testing.B) {
+	b.ReportAllocs()
+
+	for n := 0; n < b.N; n++ {
+		set := bitset.New(0)
+		for i := uint(0); i < 1<<20; i++ {
+			set.Set(i)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+// Package bloom implements Bloom filters, probabilistic sets that may report false positives,
+// but never false negatives.
+package bloom
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/igorroncevic/go-utils/util"
+)
+
+var (
+	ErrIncompatible = fmt.Errorf("filters differ in size or number of hash functions")
+	ErrMissingFuncs = fmt.Errorf("filter has no hash function, construct it with New before decoding")
+	ErrInvalidData  = fmt.Errorf("invalid bloom filter data")
+)
+
+// MaxHashes is the largest number of hash functions a filter uses. More of them only slow the filter
+// down, as even a false-positive rate close to zero needs fewer.
+const MaxHashes = 64
+
+// Filter is a Bloom filter, a bit array where every value sets k of the bits.
+// Values are hashed once, and the k positions are derived from that hash by double hashing.
+type Filter[T any] struct {
+	bits []uint64
+	m, k uint64
+
+	hash util.HashFn[T]
+}
+
+// Estimate returns the number of bits 'm' and hash functions 'k' needed to keep the false-positive rate
+// at 'falsePositiveRate' after 'expectedItems' values are added.
+func Estimate(expectedItems uint64, falsePositiveRate float64) (m, k uint64) {
+	n := math.Max(float64(expectedItems), 1)
+	p := util.Clamp(falsePositiveRate, math.SmallestNonzeroFloat64, 0.5)
+
+	m = uint64(math.Ceil(-n * math.Log(p) / (math.Ln2 * math.Ln2)))
+	k = uint64(math.Round(float64(m) / n * math.Ln2))
+
+	return util.Max(m, 1), util.Clamp(k, 1, MaxHashes)
+}
+
+// New constructs a new filter sized for 'expectedItems' values at the given false-positive rate,
+// which hashes values with 'hash', e.g. util.HashString or util.HashBytes.
+func New[T any](expectedItems uint64, falsePositiveRate float64, hash util.HashFn[T]) *Filter[T] {
+	m, k := Estimate(expectedItems, falsePositiveRate)
+	return NewWithSize(m, k, hash)
+}
+
+// NewWithSize constructs a new filter with 'm' bits and 'k' hash functions, at most MaxHashes of them.
+func NewWithSize[T any](m, k uint64, hash util.HashFn[T]) *Filter[T] {
+	m, k = util.Max(m, 1), util.Clamp(k, 1, MaxHashes)
+
+	return &Filter[T]{
+		bits: make([]uint64, (m+63)/64),
+		m:    m,
+		k:    k,
+		hash: hash,
+	}
+}
+
+// Add adds the value to the filter.
+func (f *Filter[T]) Add(val T) {
+	h1, h2 := hashes(f.hash(val))
+
+	for i := uint64(0); i < f.k; i++ {
+		idx := location(h1, h2, i, f.m)
+		f.bits[idx/64] |= 1 << (idx % 64)
+	}
+}
+
+// Contains returns whether the value may have been added to the filter.
+// False means that it definitely was not.
+func (f *Filter[T]) Contains(val T) bool {
+	h1, h2 := hashes(f.hash(val))
+
+	for i := uint64(0); i < f.k; i++ {
+		idx := location(h1, h2, i, f.m)
+		if f.bits[idx/64]&(1<<(idx%64)) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Union adds all of the values of 'other' to this filter. Both filters must have
+// the same size and number of hash functions, and should use the same hash function.
+func (f *Filter[T]) Union(other *Filter[T]) error {
+	if f.m != other.m || f.k != other.k {
+		return ErrIncompatible
+	}
+
+	for i, word := range other.bits {
+		f.bits[i] |= word
+	}
+
+	return nil
+}
+
+// Clear removes all values from the filter.
+func (f *Filter[T]) Clear() {
+	for i := range f.bits {
+		f.bits[i] = 0
+	}
+}
+
+// Cap returns the number of bits in the filter.
+func (f *Filter[T]) Cap() uint64 {
+	return f.m
+}
+
+// K returns the number of hash functions of the filter.
+func (f *Filter[T]) K() uint64 {
+	return f.k
+}
+
+// Copy returns a copy of this filter.
+func (f *Filter[T]) Copy() *Filter[T] {
+	copy := NewWithSize(f.m, f.k, f.hash)
+	_ = copy.Union(f)
+
+	return copy
+}
+
+// MarshalBinary encodes the size and number of hash functions of the filter, followed by its bits.
+// The hash function is not encoded, so the decoding filter must use the same one.
+func (f *Filter[T]) MarshalBinary() ([]byte, error) {
+	data := appendHeader(make([]byte, 0, 16+8*len(f.bits)), f.m, f.k)
+
+	for _, word := range f.bits {
+		data = binary.LittleEndian.AppendUint64(data, word)
+	}
+
+	return data, nil
+}
+
+// UnmarshalBinary replaces the filter with the one encoded by MarshalBinary.
+// The filter must be constructed with New beforehand, so that it knows how to hash values.
+func (f *Filter[T]) UnmarshalBinary(data []byte) error {
+	if f.hash == nil {
+		return ErrMissingFuncs
+	}
+
+	m, k, body, err := readHeader(data)
+	if err != nil {
+		return err
+	}
+
+	// Checking that the bits fit into the body first keeps the rounding below from overflowing
+	if m > uint64(len(body))*8 || uint64(len(body)) != (m+63)/64*8 {
+		return ErrInvalidData
+	}
+
+	f.m, f.k = m, k
+	f.bits = make([]uint64, len(body)/8)
+
+	for i := range f.bits {
+		f.bits[i] = binary.LittleEndian.Uint64(body[i*8:])
+	}
+
+	return nil
+}
+
+// hashes derives the two hashes used for double hashing from a single hash.
+// The second one is odd, so that it never gets stuck on the same location.
+func hashes(hash uint64) (uint64, uint64) {
+	return hash, util.HashUint64(hash) | 1
+}
+
+// location returns the position picked by the i-th hash function.
+func location(h1, h2, i, m uint64) uint64 {
+	return (h1 + i*h2) % m
+}
+
+func appendHeader(data []byte, m, k uint64) []byte {
+	data = binary.LittleEndian.AppendUint64(data, m)
+	return binary.LittleEndian.AppendUint64(data, k)
+}
+
+// readHeader reads what appendHeader wrote and returns the data after it.
+func readHeader(data []byte) (m, k uint64, body []byte, err error) {
+	if len(data) < 16 {
+		return 0, 0, nil, ErrInvalidData
+	}
+
+	m, k = binary.LittleEndian.Uint64(data), binary.LittleEndian.Uint64(data[8:])
+	if m == 0 || k == 0 || k > MaxHashes {
+		return 0, 0, nil, ErrInvalidData
+	}
+
+	return m, k, data[16:], nil
+}
@@ -0,0 +1,97 @@
+package bloom_test
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/igorroncevic/go-utils/bloom"
+	"github.com/igorroncevic/go-utils/util"
+)
+
+// falsePositives counts how many of 'tries' values that were never added are reported as present.
+func falsePositives(contains func(val string) bool, tries int) int {
+	var count int
+
+	for i := 0; i < tries; i++ {
+		if contains("missing-" + strconv.Itoa(i)) {
+			count++
+		}
+	}
+
+	return count
+}
+
+func TestEstimate(t *testing.T) {
+	m, k := bloom.Estimate(1000, 0.01)
+	assert.Equal(t, uint64(9586), m)
+	assert.Equal(t, uint64(7), k)
+
+	m, k = bloom.Estimate(0, 0)
+	assert.True(t, m >= 1)
+	assert.True(t, k >= 1)
+}
+
+func TestFilter(t *testing.T) {
+	f := bloom.New(10000, 0.01, util.HashString)
+
+	for i := 0; i < 10000; i++ {
+		f.Add(strconv.Itoa(i))
+	}
+
+	for i := 0; i < 10000; i++ {
+		assert.True(t, f.Contains(strconv.Itoa(i)))
+	}
+
+	// Allow some slack above the target rate
+	assert.True(t, falsePositives(f.Contains, 10000) < 150)
+
+	f.Clear()
+	assert.False(t, f.Contains("1"))
+}
+
+func TestFilterUnion(t *testing.T) {
+	a := bloom.New(100, 0.01, util.HashString)
+	b := bloom.New(100, 0.01, util.HashString)
+
+	a.Add("foo")
+	b.Add("bar")
+
+	copy := a.Copy()
+
+	assert.NoError(t, a.Union(b))
+	assert.True(t, a.Contains("foo"))
+	assert.True(t, a.Contains("bar"))
+	assert.False(t, copy.Contains("bar"))
+
+	assert.Equal(t, bloom.ErrIncompatible, a.Union(bloom.New(1000, 0.01, util.HashString)))
+}
+
+func TestFilterBinary(t *testing.T) {
+	f := bloom.New(100, 0.01, util.HashBytes)
+	f.Add([]byte("foo"))
+	f.Add([]byte("bar"))
+
+	data, err := f.MarshalBinary()
+	assert.NoError(t, err)
+
+	decoded := bloom.NewWithSize(1, 1, util.HashBytes)
+	assert.NoError(t, decoded.UnmarshalBinary(data))
+	assert.Equal(t, f.Cap(), decoded.Cap())
+	assert.Equal(t, f.K(), decoded.K())
+	assert.True(t, decoded.Contains([]byte("foo")))
+	assert.True(t, decoded.Contains([]byte("bar")))
+
+	assert.Equal(t, bloom.ErrInvalidData, decoded.UnmarshalBinary(data[:len(data)-1]))
+	assert.Equal(t, bloom.ErrMissingFuncs, (&bloom.Filter[[]byte]{}).UnmarshalBinary(data))
+
+	// Sizes that would overflow when rounded up to whole words are rejected
+	huge := append(bytes.Repeat([]byte{0xFF}, 8), 1, 0, 0, 0, 0, 0, 0, 0)
+	assert.Equal(t, bloom.ErrInvalidData, decoded.UnmarshalBinary(huge))
+
+	// So is a number of hash functions no filter would use, here 2^40 of them
+	manyHashes := append([]byte{64, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0}, make([]byte, 8)...)
+	assert.Equal(t, bloom.ErrInvalidData, decoded.UnmarshalBinary(manyHashes))
+	assert.True(t, decoded.Contains([]byte("foo")))
+}
@@ -0,0 +1,151 @@
+package bloom
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/igorroncevic/go-utils/util"
+)
+
+var (
+	ErrNotFound = fmt.Errorf("value is not in the filter")
+)
+
+// CountingFilter is a Bloom filter that keeps a counter instead of a bit at every position,
+// which makes it possible to remove values. Counters stick at their maximum once they reach it,
+// since they no longer know how many values they count.
+type CountingFilter[T any] struct {
+	counters []uint8
+	k        uint64
+
+	hash util.HashFn[T]
+}
+
+// NewCounting constructs a new counting filter sized for 'expectedItems' values at the given false-positive rate.
+func NewCounting[T any](expectedItems uint64, falsePositiveRate float64, hash util.HashFn[T]) *CountingFilter[T] {
+	m, k := Estimate(expectedItems, falsePositiveRate)
+	return NewCountingWithSize(m, k, hash)
+}
+
+// NewCountingWithSize constructs a new counting filter with 'm' counters and 'k' hash functions,
+// at most MaxHashes of them.
+func NewCountingWithSize[T any](m, k uint64, hash util.HashFn[T]) *CountingFilter[T] {
+	return &CountingFilter[T]{
+		counters: make([]uint8, util.Max(m, 1)),
+		k:        util.Clamp(k, 1, MaxHashes),
+		hash:     hash,
+	}
+}
+
+// Add adds the value to the filter.
+func (f *CountingFilter[T]) Add(val T) {
+	h1, h2 := hashes(f.hash(val))
+
+	for i := uint64(0); i < f.k; i++ {
+		idx := location(h1, h2, i, f.Cap())
+		if f.counters[idx] < math.MaxUint8 {
+			f.counters[idx]++
+		}
+	}
+}
+
+// Remove removes one occurrence of the value from the filter, or returns ErrNotFound if it is not in it.
+// Removing a value that was never added, but is a false positive, corrupts the filter.
+func (f *CountingFilter[T]) Remove(val T) error {
+	if !f.Contains(val) {
+		return ErrNotFound
+	}
+
+	h1, h2 := hashes(f.hash(val))
+
+	for i := uint64(0); i < f.k; i++ {
+		idx := location(h1, h2, i, f.Cap())
+		if f.counters[idx] < math.MaxUint8 {
+			f.counters[idx]--
+		}
+	}
+
+	return nil
+}
+
+// Contains returns whether the value may be in the filter. False means that it definitely is not.
+func (f *CountingFilter[T]) Contains(val T) bool {
+	h1, h2 := hashes(f.hash(val))
+
+	for i := uint64(0); i < f.k; i++ {
+		if f.counters[location(h1, h2, i, f.Cap())] == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Union adds all of the values of 'other' to this filter. Both filters must have
+// the same size and number of hash functions, and should use the same hash function.
+func (f *CountingFilter[T]) Union(other *CountingFilter[T]) error {
+	if f.Cap() != other.Cap() || f.k != other.k {
+		return ErrIncompatible
+	}
+
+	for i, count := range other.counters {
+		f.counters[i] = uint8(util.Min(int(f.counters[i])+int(count), math.MaxUint8))
+	}
+
+	return nil
+}
+
+// Clear removes all values from the filter.
+func (f *CountingFilter[T]) Clear() {
+	for i := range f.counters {
+		f.counters[i] = 0
+	}
+}
+
+// Cap returns the number of counters in the filter.
+func (f *CountingFilter[T]) Cap() uint64 {
+	return uint64(len(f.counters))
+}
+
+// K returns the number of hash functions of the filter.
+func (f *CountingFilter[T]) K() uint64 {
+	return f.k
+}
+
+// Copy returns a copy of this filter.
+func (f *CountingFilter[T]) Copy() *CountingFilter[T] {
+	copy := NewCountingWithSize(f.Cap(), f.k, f.hash)
+	_ = copy.Union(f)
+
+	return copy
+}
+
+// MarshalBinary encodes the size and number of hash functions of the filter, followed by its counters.
+// The hash function is not encoded, so the decoding filter must use the same one.
+func (f *CountingFilter[T]) MarshalBinary() ([]byte, error) {
+	data := appendHeader(make([]byte, 0, 16+len(f.counters)), f.Cap(), f.k)
+	return append(data, f.counters...), nil
+}
+
+// UnmarshalBinary replaces the filter with the one encoded by MarshalBinary.
+// The filter must be constructed with NewCounting beforehand, so that it knows how to hash values.
+func (f *CountingFilter[T]) UnmarshalBinary(data []byte) error {
+	if f.hash == nil {
+		return ErrMissingFuncs
+	}
+
+	m, k, body, err := readHeader(data)
+	if err != nil {
+		return err
+	}
+
+	if uint64(len(body)) != m {
+		return ErrInvalidData
+	}
+
+	f.k = k
+	f.counters = make([]uint8, m)
+	copy(f.counters, body)
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package bloom_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/igorroncevic/go-utils/bloom"
+	"github.com/igorroncevic/go-utils/util"
+)
+
+func TestCountingFilter(t *testing.T) {
+	f := bloom.NewCounting(10000, 0.01, util.HashString)
+
+	for i := 0; i < 10000; i++ {
+		f.Add(strconv.Itoa(i))
+	}
+
+	assert.True(t, falsePositives(f.Contains, 10000) < 150)
+
+	for i := 0; i < 10000; i += 2 {
+		assert.NoError(t, f.Remove(strconv.Itoa(i)))
+	}
+
+	// Removed values may still be false positives, but remaining ones are never missing
+	for i := 1; i < 10000; i += 2 {
+		assert.True(t, f.Contains(strconv.Itoa(i)))
+	}
+
+	assert.True(t, falsePositives(f.Contains, 10000) < 50)
+}
+
+func TestCountingFilterRemove(t *testing.T) {
+	f := bloom.NewCounting(100, 0.01, util.HashString)
+
+	f.Add("foo")
+	f.Add("foo")
+
+	assert.NoError(t, f.Remove("foo"))
+	assert.True(t, f.Contains("foo"))
+	assert.NoError(t, f.Remove("foo"))
+	assert.False(t, f.Contains("foo"))
+	assert.Equal(t, bloom.ErrNotFound, f.Remove("foo"))
+}
+
+func TestCountingFilterUnion(t *testing.T) {
+	a := bloom.NewCounting(100, 0.01, util.HashString)
+	b := bloom.NewCounting(100, 0.01, util.HashString)
+
+	a.Add("foo")
+	b.Add("foo")
+	b.Add("bar")
+
+	assert.NoError(t, a.Union(b))
+	assert.True(t, a.Contains("bar"))
+
+	// Both occurrences of "foo" were counted
+	assert.NoError(t, a.Remove("foo"))
+	assert.True(t, a.Contains("foo"))
+
+	assert.Equal(t, bloom.ErrIncompatible, a.Union(bloom.NewCounting(1000, 0.01, util.HashString)))
+}
+
+func TestCountingFilterBinary(t *testing.T) {
+	f := bloom.NewCounting(100, 0.01, util.HashString)
+	f.Add("foo")
+
+	data, err := f.MarshalBinary()
+	assert.NoError(t, err)
+
+	decoded := bloom.NewCountingWithSize(1, 1, util.HashString)
+	assert.NoError(t, decoded.UnmarshalBinary(data))
+	assert.True(t, decoded.Contains("foo"))
+	assert.NoError(t, decoded.Remove("foo"))
+	assert.False(t, decoded.Contains("foo"))
+
+	assert.Equal(t, bloom.ErrInvalidData, decoded.UnmarshalBinary(data[:10]))
+
+	manyHashes := append([]byte{1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0}, 0)
+	assert.Equal(t, bloom.ErrInvalidData, decoded.UnmarshalBinary(manyHashes))
+}
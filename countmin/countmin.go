@@ -0,0 +1,123 @@
+// Package countmin implements the Count-Min sketch, which estimates how many times each key
+// occurred in a stream using a fixed amount of memory. See "An Improved Data Stream Summary:
+// The Count-Min Sketch and its Applications" by Cormode and Muthukrishnan.
+package countmin
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/igorroncevic/go-utils/util"
+)
+
+var (
+	ErrIncompatible = fmt.Errorf("sketches have different dimensions")
+)
+
+// Sketch is a Count-Min sketch, a grid of counters where every row maps each key to one of its counters.
+// Estimates are never lower than the actual counts, and exceed them by at most epsilon * Total()
+// with probability 1 - delta, where width = e / epsilon and depth = ln(1 / delta).
+//
+// Counts are added with conservative update, which only raises the counters that are at the
+// current estimate. It considerably lowers the overestimation, but rules out decrements.
+type Sketch[T any] struct {
+	// counters holds all of the rows one after another.
+	counters     []uint64
+	width, depth uint64
+	total        uint64
+
+	hash util.HashFn[T]
+}
+
+// New constructs a new sketch with 'depth' rows of 'width' counters, which hashes keys with 'hash', e.g. util.HashString.
+func New[T any](width, depth uint64, hash util.HashFn[T]) *Sketch[T] {
+	width, depth = util.Max(width, 1), util.Max(depth, 1)
+
+	return &Sketch[T]{
+		counters: make([]uint64, width*depth),
+		width:    width,
+		depth:    depth,
+		hash:     hash,
+	}
+}
+
+// NewWithEstimates constructs a new sketch whose estimates exceed the actual counts
+// by at most epsilon * Total() with probability 1 - delta.
+func NewWithEstimates[T any](epsilon, delta float64, hash util.HashFn[T]) *Sketch[T] {
+	width := math.Ceil(math.E / epsilon)
+	depth := math.Ceil(math.Log(1 / delta))
+
+	return New(uint64(width), uint64(depth), hash)
+}
+
+// Add adds 'n' occurrences of the key.
+func (s *Sketch[T]) Add(key T, n uint64) {
+	h1, h2 := hashes(s.hash(key))
+
+	// Raising a counter above the new estimate would only add to the error of other keys that share it
+	target := s.estimate(h1, h2) + n
+
+	for row := uint64(0); row < s.depth; row++ {
+		if idx := s.index(h1, h2, row); s.counters[idx] < target {
+			s.counters[idx] = target
+		}
+	}
+
+	s.total += n
+}
+
+// Estimate returns the estimated number of occurrences of the key, which is never lower than the actual one.
+func (s *Sketch[T]) Estimate(key T) uint64 {
+	return s.estimate(hashes(s.hash(key)))
+}
+
+// Total returns the number of occurrences added to the sketch.
+func (s *Sketch[T]) Total() uint64 {
+	return s.total
+}
+
+// Merge adds all of the occurrences of 'other' to this sketch. Both sketches must have
+// the same dimensions, and should use the same hash function.
+func (s *Sketch[T]) Merge(other *Sketch[T]) error {
+	if s.width != other.width || s.depth != other.depth {
+		return ErrIncompatible
+	}
+
+	for i, count := range other.counters {
+		s.counters[i] += count
+	}
+
+	s.total += other.total
+
+	return nil
+}
+
+// Clear removes all occurrences from the sketch.
+func (s *Sketch[T]) Clear() {
+	for i := range s.counters {
+		s.counters[i] = 0
+	}
+
+	s.total = 0
+}
+
+// estimate returns the smallest of the key's counters, which is the one least inflated by other keys.
+func (s *Sketch[T]) estimate(h1, h2 uint64) uint64 {
+	min := uint64(math.MaxUint64)
+
+	for row := uint64(0); row < s.depth; row++ {
+		min = util.Min(min, s.counters[s.index(h1, h2, row)])
+	}
+
+	return min
+}
+
+// index returns the position of the key's counter in the given row.
+func (s *Sketch[T]) index(h1, h2, row uint64) uint64 {
+	return row*s.width + (h1+row*h2)%s.width
+}
+
+// hashes derives the two hashes used for double hashing from a single hash.
+func hashes(hash uint64) (uint64, uint64) {
+	return hash, util.HashUint64(hash) | 1
+}
@@ -0,0 +1,68 @@
+package countmin_test
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/igorroncevic/go-utils/countmin"
+	"github.com/igorroncevic/go-utils/util"
+)
+
+func TestSketch(t *testing.T) {
+	var (
+		r      = rand.New(rand.NewSource(1)) //nolint:gosec // deterministic test data
+		zipf   = rand.NewZipf(r, 1.2, 1, 100000)
+		s      = countmin.NewWithEstimates(0.001, 0.01, util.HashUint64)
+		actual = map[uint64]uint64{}
+	)
+
+	for i := 0; i < 200000; i++ {
+		key := zipf.Uint64()
+		n := uint64(r.Intn(3) + 1)
+
+		s.Add(key, n)
+		actual[key] += n
+	}
+
+	bound := uint64(0.001 * float64(s.Total()))
+
+	var exceeded int
+
+	for key, count := range actual {
+		estimate := s.Estimate(key)
+
+		assert.True(t, estimate >= count)
+
+		if estimate-count > bound {
+			exceeded++
+		}
+	}
+
+	// The bound holds with probability 1 - delta
+	assert.True(t, float64(exceeded) <= 0.01*float64(len(actual)))
+}
+
+func TestSketchMerge(t *testing.T) {
+	a := countmin.New(1000, 5, util.HashString)
+	b := countmin.New(1000, 5, util.HashString)
+
+	for i := 0; i < 100; i++ {
+		a.Add("key-"+strconv.Itoa(i), 2)
+		b.Add("key-"+strconv.Itoa(i), 3)
+	}
+
+	assert.NoError(t, a.Merge(b))
+	assert.Equal(t, uint64(500), a.Total())
+
+	for i := 0; i < 100; i++ {
+		assert.True(t, a.Estimate("key-"+strconv.Itoa(i)) >= 5)
+	}
+
+	assert.Equal(t, countmin.ErrIncompatible, a.Merge(countmin.New(1000, 4, util.HashString)))
+
+	a.Clear()
+	assert.Equal(t, uint64(0), a.Total())
+	assert.Equal(t, uint64(0), a.Estimate("key-1"))
+}
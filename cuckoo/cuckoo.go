@@ -0,0 +1,264 @@
+// Package cuckoo implements cuckoo filters, probabilistic sets that support deletion.
+// See "Cuckoo Filter: Practically Better Than Bloom" by Fan et al.
+package cuckoo
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/igorroncevic/go-utils/util"
+)
+
+const (
+	DefaultFingerprintBits = 16
+	DefaultBucketSize      = 4
+	DefaultMaxKicks        = 500
+)
+
+var (
+	ErrFull = fmt.Errorf("filter is full")
+)
+
+// Config tunes the filter. Zero fields are replaced with their defaults.
+type Config struct {
+	// FingerprintBits is the number of bits stored per value, between 1 and 32.
+	// Every extra bit halves the false-positive rate.
+	FingerprintBits uint
+	// BucketSize is the number of fingerprints per bucket. Bigger buckets allow higher
+	// load factors, but need longer fingerprints for the same false-positive rate.
+	BucketSize uint
+	// MaxKicks is the number of fingerprints relocated before an insertion gives up.
+	MaxKicks uint
+}
+
+// Filter is a cuckoo filter, which stores a short fingerprint of every value in one of
+// two buckets. The other bucket is derived from the fingerprint alone, which lets
+// fingerprints be moved between their two buckets to make room for new ones.
+type Filter[T any] struct {
+	// table holds all of the buckets one after another, where 0 marks an empty slot.
+	table      []uint32
+	numBuckets uint64
+	count      uint64
+	config     Config
+	random     xorshift
+
+	hash util.HashFn[T]
+}
+
+// New constructs a new filter with room for at least 'capacity' values, with the default configuration.
+func New[T any](capacity uint64, hash util.HashFn[T]) *Filter[T] {
+	return NewWithConfig(capacity, Config{}, hash)
+}
+
+// NewWithConfig constructs a new filter with room for at least 'capacity' values.
+func NewWithConfig[T any](capacity uint64, config Config, hash util.HashFn[T]) *Filter[T] {
+	if config.FingerprintBits == 0 {
+		config.FingerprintBits = DefaultFingerprintBits
+	}
+
+	if config.BucketSize == 0 {
+		config.BucketSize = DefaultBucketSize
+	}
+
+	if config.MaxKicks == 0 {
+		config.MaxKicks = DefaultMaxKicks
+	}
+
+	config.FingerprintBits = util.Min(config.FingerprintBits, 32)
+
+	// The alternate bucket is found with a XOR, which needs a power of two number of buckets
+	numBuckets := pow2ceil(util.Max(capacity/uint64(config.BucketSize), 1))
+	for float64(capacity)/float64(numBuckets*uint64(config.BucketSize)) > maxLoadFactor(config.BucketSize) {
+		numBuckets *= 2
+	}
+
+	seed, err := util.RandomInt64(math.MaxInt64)
+	if err != nil {
+		seed = 1
+	}
+
+	return &Filter[T]{
+		table:      make([]uint32, numBuckets*uint64(config.BucketSize)),
+		numBuckets: numBuckets,
+		config:     config,
+		random:     xorshift{state: uint64(seed) | 1},
+		hash:       hash,
+	}
+}
+
+// Insert adds the value to the filter. Values can be inserted more than once, as long as they fit into their buckets.
+// If there is no room for the value, even after relocating other values, ErrFull is returned and the filter is left as it was.
+func (f *Filter[T]) Insert(val T) error {
+	fp, i1 := f.fingerprint(val)
+	i2 := f.altIndex(i1, fp)
+
+	if f.insertInto(i1, fp) || f.insertInto(i2, fp) {
+		return nil
+	}
+
+	type slot struct {
+		bucket, idx uint64
+	}
+
+	var (
+		kicked = make([]slot, 0, f.config.MaxKicks)
+		bucket = i1
+	)
+
+	if f.random.next()&1 == 0 {
+		bucket = i2
+	}
+
+	for n := uint(0); n < f.config.MaxKicks; n++ {
+		// Take the place of a random fingerprint, then move that one to its other bucket
+		s := slot{bucket, f.random.next() % uint64(f.config.BucketSize)}
+		kicked = append(kicked, s)
+
+		pos := s.bucket*uint64(f.config.BucketSize) + s.idx
+		fp, f.table[pos] = f.table[pos], fp
+
+		bucket = f.altIndex(bucket, fp)
+		if f.insertInto(bucket, fp) {
+			return nil
+		}
+	}
+
+	// Undo the relocations, so that no fingerprint gets lost
+	for i := len(kicked) - 1; i >= 0; i-- {
+		pos := kicked[i].bucket*uint64(f.config.BucketSize) + kicked[i].idx
+		fp, f.table[pos] = f.table[pos], fp
+	}
+
+	return ErrFull
+}
+
+// Lookup returns whether the value may be in the filter. False means that it definitely is not.
+func (f *Filter[T]) Lookup(val T) bool {
+	fp, i1 := f.fingerprint(val)
+
+	return f.find(i1, fp) >= 0 || f.find(f.altIndex(i1, fp), fp) >= 0
+}
+
+// Delete removes one occurrence of the value from the filter and returns whether it was found.
+// Deleting a value that was never inserted, but is a false positive, removes some other value instead.
+func (f *Filter[T]) Delete(val T) bool {
+	fp, i1 := f.fingerprint(val)
+
+	for _, bucket := range []uint64{i1, f.altIndex(i1, fp)} {
+		if pos := f.find(bucket, fp); pos >= 0 {
+			f.table[pos] = 0
+			f.count--
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// Count returns the number of values in the filter.
+func (f *Filter[T]) Count() uint64 {
+	return f.count
+}
+
+// Cap returns the number of fingerprints the filter has room for.
+func (f *Filter[T]) Cap() uint64 {
+	return uint64(len(f.table))
+}
+
+// LoadFactor returns the ratio of used slots to all of them.
+func (f *Filter[T]) LoadFactor() float64 {
+	return float64(f.count) / float64(f.Cap())
+}
+
+// Clear removes all values from the filter.
+func (f *Filter[T]) Clear() {
+	for i := range f.table {
+		f.table[i] = 0
+	}
+
+	f.count = 0
+}
+
+// fingerprint returns the value's fingerprint, which is never 0, and its primary bucket.
+// Both are taken from different bits of the hash, so that they are independent.
+func (f *Filter[T]) fingerprint(val T) (uint32, uint64) {
+	// The hash is mixed once more, since the upper bits of some hashes, like FNV, are poorly distributed
+	hash := util.HashUint64(f.hash(val))
+
+	fp := uint32(hash>>32) & (1<<f.config.FingerprintBits - 1)
+	if fp == 0 {
+		fp = 1
+	}
+
+	return fp, hash & (f.numBuckets - 1)
+}
+
+// altIndex returns the other bucket of the fingerprint, given one of its buckets.
+func (f *Filter[T]) altIndex(bucket uint64, fp uint32) uint64 {
+	return (bucket ^ util.HashUint32(fp)) & (f.numBuckets - 1)
+}
+
+// insertInto puts the fingerprint into an empty slot of the bucket, returning false if it is full.
+func (f *Filter[T]) insertInto(bucket uint64, fp uint32) bool {
+	if pos := f.find(bucket, 0); pos >= 0 {
+		f.table[pos] = fp
+		f.count++
+
+		return true
+	}
+
+	return false
+}
+
+// find returns the position of the fingerprint in the table if it is in the bucket, or -1 otherwise.
+func (f *Filter[T]) find(bucket uint64, fp uint32) int {
+	start := int(bucket * uint64(f.config.BucketSize))
+
+	for pos := start; pos < start+int(f.config.BucketSize); pos++ {
+		if f.table[pos] == fp {
+			return pos
+		}
+	}
+
+	return -1
+}
+
+// maxLoadFactor returns the load up to which insertions reliably succeed with the given bucket size,
+// a bit below the limits measured in the paper, which are 50%, 84%, 95% and 98% for buckets of 1, 2, 4 and 8.
+func maxLoadFactor(bucketSize uint) float64 {
+	switch {
+	case bucketSize == 1:
+		return 0.45
+	case bucketSize < 4:
+		return 0.8
+	case bucketSize < 8:
+		return 0.9
+	default:
+		return 0.95
+	}
+}
+
+// pow2ceil returns the smallest power of two that is greater than or equal to 'num'.
+func pow2ceil(num uint64) uint64 {
+	power := uint64(1)
+
+	for power < num {
+		power *= 2
+	}
+
+	return power
+}
+
+// xorshift picks the fingerprints to relocate.
+type xorshift struct {
+	state uint64
+}
+
+func (x *xorshift) next() uint64 {
+	x.state ^= x.state << 13
+	x.state ^= x.state >> 7
+	x.state ^= x.state << 17
+
+	return x.state
+}
@@ -0,0 +1,111 @@
+package cuckoo_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/igorroncevic/go-utils/cuckoo"
+	"github.com/igorroncevic/go-utils/util"
+)
+
+func TestFilter(t *testing.T) {
+	f := cuckoo.New(10000, util.HashString)
+
+	for i := 0; i < 10000; i++ {
+		assert.NoError(t, f.Insert(strconv.Itoa(i)))
+	}
+
+	assert.Equal(t, uint64(10000), f.Count())
+	assert.True(t, f.LoadFactor() > 0.5 && f.LoadFactor() <= 1)
+
+	for i := 0; i < 10000; i++ {
+		assert.True(t, f.Lookup(strconv.Itoa(i)))
+	}
+
+	var falsePositives int
+
+	for i := 0; i < 10000; i++ {
+		if f.Lookup("missing-" + strconv.Itoa(i)) {
+			falsePositives++
+		}
+	}
+
+	assert.True(t, falsePositives < 10)
+
+	for i := 0; i < 10000; i += 2 {
+		assert.True(t, f.Delete(strconv.Itoa(i)))
+	}
+
+	assert.Equal(t, uint64(5000), f.Count())
+
+	for i := 1; i < 10000; i += 2 {
+		assert.True(t, f.Lookup(strconv.Itoa(i)))
+	}
+
+	f.Clear()
+	assert.Equal(t, uint64(0), f.Count())
+	assert.False(t, f.Lookup("1"))
+}
+
+func TestFilterDuplicates(t *testing.T) {
+	f := cuckoo.New(100, util.HashBytes)
+
+	assert.NoError(t, f.Insert([]byte("foo")))
+	assert.NoError(t, f.Insert([]byte("foo")))
+	assert.Equal(t, uint64(2), f.Count())
+
+	assert.True(t, f.Delete([]byte("foo")))
+	assert.True(t, f.Lookup([]byte("foo")))
+	assert.True(t, f.Delete([]byte("foo")))
+	assert.False(t, f.Lookup([]byte("foo")))
+	assert.False(t, f.Delete([]byte("foo")))
+}
+
+func TestFilterFull(t *testing.T) {
+	f := cuckoo.NewWithConfig(64, cuckoo.Config{FingerprintBits: 12, BucketSize: 2, MaxKicks: 50}, util.HashString)
+
+	var (
+		inserted []string
+		err      error
+	)
+
+	for i := 0; err == nil; i++ {
+		val := strconv.Itoa(i)
+
+		if err = f.Insert(val); err == nil {
+			inserted = append(inserted, val)
+		}
+	}
+
+	assert.Equal(t, cuckoo.ErrFull, err)
+	assert.Equal(t, uint64(len(inserted)), f.Count())
+	assert.True(t, f.Count() <= f.Cap())
+
+	// A failed insertion does not lose any of the values that were already in
+	for _, val := range inserted {
+		assert.True(t, f.Lookup(val))
+	}
+}
+
+func TestFilterCapacity(t *testing.T) {
+	configs := []cuckoo.Config{
+		{},
+		{FingerprintBits: 8, BucketSize: 2},
+		{FingerprintBits: 8, BucketSize: 4},
+		{FingerprintBits: 12, BucketSize: 8},
+		{FingerprintBits: 32, BucketSize: 1},
+	}
+
+	for _, config := range configs {
+		for _, capacity := range []uint64{100, 1000, 10000} {
+			f := cuckoo.NewWithConfig(capacity, config, util.HashString)
+
+			for i := uint64(0); i < capacity; i++ {
+				assert.NoError(t, f.Insert("key-"+strconv.FormatUint(i, 10)), "config %+v, capacity %d", config, capacity)
+			}
+
+			assert.Equal(t, capacity, f.Count())
+		}
+	}
+}
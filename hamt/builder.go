@@ -0,0 +1,65 @@
+package hamt
+
+// Builder is a transient, mutable view of a Map meant for batch construction.
+// Nodes created by the builder are modified in place instead of being copied on every write,
+// while nodes shared with existing maps are still copied before they are changed.
+//
+//	b := hamt.New[string, int](util.Equals[string], util.HashString).Transient()
+//	b.Put("foo", 1)
+//	b.Put("bar", 2)
+//	m := b.Persistent()
+type Builder[K, V any] struct {
+	root   *node[K, V]
+	length int
+	edit   *edit
+
+	ops ops[K]
+}
+
+// Get returns the value stored for this key, or false if there is no such value.
+func (b *Builder[K, V]) Get(key K) (V, bool) {
+	return b.root.get(0, b.ops.hash(key), key, b.ops.equals)
+}
+
+// Put maps the given key to the given value. If the key already exists its
+// value will be overwritten with the new value.
+func (b *Builder[K, V]) Put(key K, val V) {
+	var added bool
+
+	b.root = b.root.put(0, b.ops.hash(key), key, val, b.ops.equals, b.edit, &added)
+
+	if added {
+		b.length++
+	}
+}
+
+// Remove removes the specified key-value pair.
+func (b *Builder[K, V]) Remove(key K) {
+	var removed bool
+
+	b.root = b.root.remove(0, b.ops.hash(key), key, b.ops.equals, b.edit, &removed)
+
+	if removed {
+		b.length--
+	}
+}
+
+// Size returns the number of items in the builder.
+func (b *Builder[K, V]) Size() int {
+	return b.length
+}
+
+// Persistent returns an immutable Map with the current contents of the builder.
+// The builder stays usable afterwards, but its following writes no longer touch
+// the nodes of the returned map.
+func (b *Builder[K, V]) Persistent() *Map[K, V] {
+	m := &Map[K, V]{
+		root:   b.root,
+		length: b.length,
+		ops:    b.ops,
+	}
+
+	b.edit = &edit{}
+
+	return m
+}
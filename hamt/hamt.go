@@ -0,0 +1,367 @@
+// Package hamt implements a persistent hash array mapped trie.
+// Every write returns a new version of the map which shares most of its structure
+// with the previous one, so old versions stay valid and cheap to keep around.
+package hamt
+
+import (
+	"math/bits"
+
+	"github.com/igorroncevic/go-utils/util"
+)
+
+const (
+	bitsPerLevel = 5
+	levelMask    = 1<<bitsPerLevel - 1
+	hashBits     = 64
+)
+
+// Map is an immutable map. Put and Remove leave the receiver untouched and return a new version.
+type Map[K, V any] struct {
+	root   *node[K, V]
+	length int
+
+	ops ops[K]
+}
+
+type ops[T any] struct {
+	equals func(a, b T) bool
+	hash   func(t T) uint64
+}
+
+// node is either a bitmap indexed node, where 'bitmap' marks which of the 32 possible
+// slots are present, or a collision node (below the last level), where slots are a plain list
+// of keys with identical hashes.
+type node[K, V any] struct {
+	bitmap uint32
+	slots  []slot[K, V]
+
+	// edit marks the node as owned by a Builder, which may then mutate it in place.
+	edit *edit
+}
+
+// slot holds either a key-value pair, or a child node when 'child' is set.
+type slot[K, V any] struct {
+	hash  uint64
+	key   K
+	value V
+	child *node[K, V]
+}
+
+// edit is a unique token that identifies the nodes a single Builder is allowed to mutate.
+type edit struct {
+	_ byte // ensures every token has a distinct address
+}
+
+// New constructs a new, empty map.
+func New[K, V any](equals util.EqualsFn[K], hash util.HashFn[K]) *Map[K, V] {
+	return &Map[K, V]{
+		ops: ops[K]{
+			equals: equals,
+			hash:   hash,
+		},
+	}
+}
+
+// Get returns the value stored for this key, or false if there is no such value.
+func (m *Map[K, V]) Get(key K) (V, bool) {
+	return m.root.get(0, m.ops.hash(key), key, m.ops.equals)
+}
+
+// Put returns a new version of the map where the given key is mapped to the given value.
+func (m *Map[K, V]) Put(key K, val V) *Map[K, V] {
+	var added bool
+
+	root := m.root.put(0, m.ops.hash(key), key, val, m.ops.equals, nil, &added)
+
+	return m.with(root, added, 1)
+}
+
+// Remove returns a new version of the map without the given key.
+// If the key is not present, the same version is returned.
+func (m *Map[K, V]) Remove(key K) *Map[K, V] {
+	var removed bool
+
+	root := m.root.remove(0, m.ops.hash(key), key, m.ops.equals, nil, &removed)
+	if !removed {
+		return m
+	}
+
+	return m.with(root, removed, -1)
+}
+
+// Size returns the number of items in the map.
+func (m *Map[K, V]) Size() int {
+	return m.length
+}
+
+// Each calls 'fn' on every key-value pair in the map in no particular order.
+func (m *Map[K, V]) Each(fn func(key K, val V)) {
+	m.root.each(fn)
+}
+
+// Transient returns a Builder seeded with the contents of this map.
+// The map itself is not affected by any writes made through the builder.
+func (m *Map[K, V]) Transient() *Builder[K, V] {
+	return &Builder[K, V]{
+		root:   m.root,
+		length: m.length,
+		ops:    m.ops,
+		edit:   &edit{},
+	}
+}
+
+func (m *Map[K, V]) with(root *node[K, V], changed bool, delta int) *Map[K, V] {
+	length := m.length
+	if changed {
+		length += delta
+	}
+
+	return &Map[K, V]{
+		root:   root,
+		length: length,
+		ops:    m.ops,
+	}
+}
+
+func (n *node[K, V]) get(shift uint, hash uint64, key K, equals util.EqualsFn[K]) (V, bool) {
+	for n != nil {
+		if shift >= hashBits {
+			for _, s := range n.slots {
+				if equals(s.key, key) {
+					return s.value, true
+				}
+			}
+
+			break
+		}
+
+		bit := bitpos(hash, shift)
+		if n.bitmap&bit == 0 {
+			break
+		}
+
+		s := n.slots[n.index(bit)]
+		if s.child == nil {
+			if s.hash == hash && equals(s.key, key) {
+				return s.value, true
+			}
+
+			break
+		}
+
+		n = s.child
+		shift += bitsPerLevel
+	}
+
+	var empty V
+
+	return empty, false
+}
+
+// put returns the node with the key-value pair added. Nodes owned by 'e' are modified in place,
+// all others are copied on the way down.
+func (n *node[K, V]) put(
+	shift uint, hash uint64, key K, val V, equals util.EqualsFn[K], e *edit, added *bool,
+) *node[K, V] {
+	leaf := slot[K, V]{hash: hash, key: key, value: val}
+
+	if n == nil {
+		*added = true
+		return &node[K, V]{bitmap: bitpos(hash, shift), slots: []slot[K, V]{leaf}, edit: e}
+	}
+
+	if shift >= hashBits {
+		for i, s := range n.slots {
+			if equals(s.key, key) {
+				editable := n.editable(e)
+				editable.slots[i].value = val
+
+				return editable
+			}
+		}
+
+		*added = true
+
+		return n.insertSlot(len(n.slots), leaf, 0, e)
+	}
+
+	bit := bitpos(hash, shift)
+	idx := n.index(bit)
+
+	// Empty slot, the key-value pair goes straight in
+	if n.bitmap&bit == 0 {
+		*added = true
+		return n.insertSlot(idx, leaf, bit, e)
+	}
+
+	current := n.slots[idx]
+
+	// Descend into the child node
+	if current.child != nil {
+		child := current.child.put(shift+bitsPerLevel, hash, key, val, equals, e, added)
+		if child == current.child {
+			return n
+		}
+
+		editable := n.editable(e)
+		editable.slots[idx].child = child
+
+		return editable
+	}
+
+	// Same key, only the value changes
+	if current.hash == hash && equals(current.key, key) {
+		editable := n.editable(e)
+		editable.slots[idx].value = val
+
+		return editable
+	}
+
+	// Different key in the same slot, push both of them one level down
+	*added = true
+	editable := n.editable(e)
+	editable.slots[idx] = slot[K, V]{child: merge(shift+bitsPerLevel, current, leaf, e)}
+
+	return editable
+}
+
+// remove returns the node without the given key, or nil if the node ended up empty.
+func (n *node[K, V]) remove(shift uint, hash uint64, key K, equals util.EqualsFn[K], e *edit, removed *bool) *node[K, V] {
+	if n == nil {
+		return nil
+	}
+
+	if shift >= hashBits {
+		for i, s := range n.slots {
+			if equals(s.key, key) {
+				*removed = true
+				return n.removeSlot(i, 0, e)
+			}
+		}
+
+		return n
+	}
+
+	bit := bitpos(hash, shift)
+	if n.bitmap&bit == 0 {
+		return n
+	}
+
+	idx := n.index(bit)
+	current := n.slots[idx]
+
+	if current.child == nil {
+		if current.hash != hash || !equals(current.key, key) {
+			return n
+		}
+
+		*removed = true
+
+		return n.removeSlot(idx, bit, e)
+	}
+
+	child := current.child.remove(shift+bitsPerLevel, hash, key, equals, e, removed)
+	if !*removed {
+		return n
+	}
+
+	if child == nil {
+		return n.removeSlot(idx, bit, e)
+	}
+
+	editable := n.editable(e)
+
+	// A child with a single key-value pair is collapsed into this node
+	if len(child.slots) == 1 && child.slots[0].child == nil {
+		editable.slots[idx] = child.slots[0]
+	} else {
+		editable.slots[idx].child = child
+	}
+
+	return editable
+}
+
+func (n *node[K, V]) each(fn func(key K, val V)) {
+	if n == nil {
+		return
+	}
+
+	for _, s := range n.slots {
+		if s.child != nil {
+			s.child.each(fn)
+		} else {
+			fn(s.key, s.value)
+		}
+	}
+}
+
+// editable returns a node that can be modified by the owner of 'e', which is
+// either this node, if it's already owned by 'e', or its copy.
+func (n *node[K, V]) editable(e *edit) *node[K, V] {
+	if e != nil && n.edit == e {
+		return n
+	}
+
+	slots := make([]slot[K, V], len(n.slots), len(n.slots)+1)
+	copy(slots, n.slots)
+
+	return &node[K, V]{bitmap: n.bitmap, slots: slots, edit: e}
+}
+
+// insertSlot puts 's' at position 'idx', marking 'bit' in the bitmap.
+func (n *node[K, V]) insertSlot(idx int, s slot[K, V], bit uint32, e *edit) *node[K, V] {
+	editable := n.editable(e)
+
+	editable.slots = append(editable.slots, slot[K, V]{})
+	copy(editable.slots[idx+1:], editable.slots[idx:])
+	editable.slots[idx] = s
+	editable.bitmap |= bit
+
+	return editable
+}
+
+// removeSlot removes the slot at position 'idx', clearing 'bit' from the bitmap.
+func (n *node[K, V]) removeSlot(idx int, bit uint32, e *edit) *node[K, V] {
+	if len(n.slots) == 1 {
+		return nil
+	}
+
+	editable := n.editable(e)
+
+	copy(editable.slots[idx:], editable.slots[idx+1:])
+	editable.slots[len(editable.slots)-1] = slot[K, V]{}
+	editable.slots = editable.slots[:len(editable.slots)-1]
+	editable.bitmap &^= bit
+
+	return editable
+}
+
+// index returns the position in 'slots' for the given bit, which is the number of bits set below it.
+func (n *node[K, V]) index(bit uint32) int {
+	return bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+// merge creates a node at the given shift that holds both of the key-value pairs.
+func merge[K, V any](shift uint, a, b slot[K, V], e *edit) *node[K, V] {
+	if shift >= hashBits {
+		return &node[K, V]{slots: []slot[K, V]{a, b}, edit: e}
+	}
+
+	bitA, bitB := bitpos(a.hash, shift), bitpos(b.hash, shift)
+
+	if bitA == bitB {
+		child := merge(shift+bitsPerLevel, a, b, e)
+		return &node[K, V]{bitmap: bitA, slots: []slot[K, V]{{child: child}}, edit: e}
+	}
+
+	if bitB < bitA {
+		a, b = b, a
+	}
+
+	return &node[K, V]{bitmap: bitA | bitB, slots: []slot[K, V]{a, b}, edit: e}
+}
+
+// bitpos returns the bit that represents the hash fragment at the given shift.
+func bitpos(hash uint64, shift uint) uint32 {
+	return 1 << ((hash >> shift) & levelMask)
+}
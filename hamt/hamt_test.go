@@ -0,0 +1,125 @@
+package hamt_test
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/igorroncevic/go-utils/hamt"
+	"github.com/igorroncevic/go-utils/util"
+)
+
+// Check if the hamt holds exactly the same key-value pairs as the standard map.
+func checkeq[K comparable, V comparable](m *hamt.Map[K, V], stdmap map[K]V, t *testing.T) {
+	assert.Equal(t, len(stdmap), m.Size(), "unexpected map size")
+
+	m.Each(func(key K, val V) {
+		if ov, ok := stdmap[key]; !ok {
+			t.Fatalf("key %v should exist", key)
+		} else if val != ov {
+			t.Fatalf("value mismatch: %v != %v", val, ov)
+		}
+	})
+
+	for key, val := range stdmap {
+		if ov, ok := m.Get(key); !ok {
+			t.Fatalf("key %v should exist", key)
+		} else if val != ov {
+			t.Fatalf("value mismatch: %v != %v", val, ov)
+		}
+	}
+}
+
+func TestHamtVsStandardMapCrossCheck(t *testing.T) {
+	stdmap := make(map[int64]int64)
+	m := hamt.New[int64, int64](util.Equals[int64], util.HashInt64)
+
+	const nops = 2000
+
+	for i := 0; i < nops; i++ {
+		key, err := util.RandomInt64(1000)
+		assert.NoError(t, err, "error generating key")
+
+		op, err := util.RandomInt64(3)
+		assert.NoError(t, err, "error generating op")
+
+		switch op {
+		case 0, 1:
+			stdmap[key] = int64(i)
+			m = m.Put(key, int64(i))
+		case 2:
+			delete(stdmap, key)
+			m = m.Remove(key)
+		}
+	}
+
+	checkeq(m, stdmap, t)
+}
+
+func TestHamtPersistence(t *testing.T) {
+	v1 := hamt.New[string, int](util.Equals[string], util.HashString)
+	v2 := v1.Put("foo", 1)
+	v3 := v2.Put("bar", 2)
+	v4 := v3.Put("foo", 42)
+	v5 := v4.Remove("bar")
+
+	checkeq(v1, map[string]int{}, t)
+	checkeq(v2, map[string]int{"foo": 1}, t)
+	checkeq(v3, map[string]int{"foo": 1, "bar": 2}, t)
+	checkeq(v4, map[string]int{"foo": 42, "bar": 2}, t)
+	checkeq(v5, map[string]int{"foo": 42}, t)
+
+	// Removing a missing key keeps the same version
+	assert.True(t, v5 == v5.Remove("baz"))
+}
+
+func TestHamtCollisions(t *testing.T) {
+	constHash := func(int) uint64 { return 42 }
+	m := hamt.New[int, int](util.Equals[int], constHash)
+	stdmap := make(map[int]int)
+
+	for i := 0; i < 10; i++ {
+		m = m.Put(i, i*i)
+		stdmap[i] = i * i
+	}
+
+	checkeq(m, stdmap, t)
+
+	for i := 0; i < 10; i += 2 {
+		m = m.Remove(i)
+		delete(stdmap, i)
+	}
+
+	checkeq(m, stdmap, t)
+}
+
+func TestHamtBuilder(t *testing.T) {
+	base := hamt.New[int, int](util.Equals[int], util.HashInt).Put(-1, -1)
+	b := base.Transient()
+	stdmap := map[int]int{-1: -1}
+
+	for i := 0; i < 1000; i++ {
+		b.Put(i, i)
+		stdmap[i] = i
+	}
+
+	for i := 0; i < 1000; i += 3 {
+		b.Remove(i)
+		delete(stdmap, i)
+	}
+
+	assert.Equal(t, len(stdmap), b.Size())
+
+	m := b.Persistent()
+	checkeq(m, stdmap, t)
+
+	// Base map is not affected by the builder
+	checkeq(base, map[int]int{-1: -1}, t)
+
+	// Writes after Persistent do not leak into the returned map
+	b.Put(1, 100)
+	b.Remove(2)
+
+	v, _ := b.Get(1)
+	assert.Equal(t, 100, v)
+	checkeq(m, stdmap, t)
+}
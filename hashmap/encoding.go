@@ -0,0 +1,116 @@
+package hashmap
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+
+	"github.com/igorroncevic/go-utils/internal/encode"
+)
+
+var (
+	ErrMissingFuncs = fmt.Errorf("map has no equals or hash function, construct it with New before decoding")
+)
+
+// encodedEntry is the serialized form of a single key-value pair.
+type encodedEntry[K, V any] struct {
+	Key   K `json:"key"`
+	Value V `json:"value"`
+}
+
+// MarshalJSON encodes the map as an array of {"key", "value"} objects, ordered by the JSON
+// encoding of their keys, then of their values, so the same map always yields the same output.
+func (m *Map[K, V]) MarshalJSON() ([]byte, error) {
+	entries, err := m.sortedEntries(encode.JSON)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(entries)
+}
+
+// UnmarshalJSON replaces the contents of the map with the decoded entries.
+// The map must be constructed with New beforehand, so that it knows how to hash and compare keys.
+//
+//	m := hashmap.New[string, int](0, util.Equals[string], util.HashString)
+//	err := json.Unmarshal(data, m)
+func (m *Map[K, V]) UnmarshalJSON(data []byte) error {
+	var entries []encodedEntry[K, V]
+
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+
+	return m.load(entries)
+}
+
+// MarshalBinary encodes the map using gob, ordered by the gob encoding of the keys, then of the values.
+func (m *Map[K, V]) MarshalBinary() ([]byte, error) {
+	entries, err := m.sortedEntries(encode.Gob)
+	if err != nil {
+		return nil, err
+	}
+
+	return encode.Gob(entries)
+}
+
+// UnmarshalBinary replaces the contents of the map with the entries encoded by MarshalBinary.
+// Same as with UnmarshalJSON, the map must be constructed with New beforehand.
+func (m *Map[K, V]) UnmarshalBinary(data []byte) error {
+	var entries []encodedEntry[K, V]
+
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&entries); err != nil {
+		return err
+	}
+
+	return m.load(entries)
+}
+
+// GobEncode implements gob.GobEncoder.
+func (m *Map[K, V]) GobEncode() ([]byte, error) {
+	return m.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder.
+func (m *Map[K, V]) GobDecode(data []byte) error {
+	return m.UnmarshalBinary(data)
+}
+
+// sortedEntries returns all of the entries ordered by the encoded representation of their keys,
+// and of their values when keys encode the same.
+func (m *Map[K, V]) sortedEntries(encodeFn func(val any) ([]byte, error)) ([]encodedEntry[K, V], error) {
+	entries := make([]encodedEntry[K, V], 0, m.length)
+
+	m.Each(func(key K, val V) {
+		entries = append(entries, encodedEntry[K, V]{Key: key, Value: val})
+	})
+
+	err := encode.SortByEncoding(entries, encodeFn, func(ent encodedEntry[K, V]) []any {
+		return []any{ent.Key, ent.Value}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// load resets the map and fills it with the decoded entries.
+func (m *Map[K, V]) load(entries []encodedEntry[K, V]) error {
+	if m.ops.equals == nil || m.ops.hash == nil {
+		return ErrMissingFuncs
+	}
+
+	capacity := pow2ceil(uint64(len(entries)) * 2)
+
+	m.replaceEntries(make([]entry[K, V], capacity))
+	m.capacity = capacity
+	m.length = 0
+
+	for _, ent := range entries {
+		m.Put(ent.Key, ent.Value)
+	}
+
+	return nil
+}
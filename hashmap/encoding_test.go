@@ -0,0 +1,97 @@
+package hashmap_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/igorroncevic/go-utils/hashmap"
+	"github.com/igorroncevic/go-utils/util"
+)
+
+func TestHashmapJSON(t *testing.T) {
+	hmap := hashmap.New[string, int](1, util.Equals[string], util.HashString)
+	hmap.Put("foo", 42)
+	hmap.Put("bar", 13)
+	hmap.Put("baz", 7)
+
+	data, err := json.Marshal(hmap)
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"key":"bar","value":13},{"key":"baz","value":7},{"key":"foo","value":42}]`, string(data))
+
+	// Output is deterministic regardless of insertion order
+	other := hashmap.New[string, int](16, util.Equals[string], util.HashString)
+	other.Put("baz", 7)
+	other.Put("foo", 42)
+	other.Put("bar", 13)
+
+	otherData, err := json.Marshal(other)
+	assert.NoError(t, err)
+	assert.Equal(t, string(data), string(otherData))
+
+	decoded := hashmap.New[string, int](0, util.Equals[string], util.HashString)
+	assert.NoError(t, json.Unmarshal(data, decoded))
+	assert.Equal(t, 3, decoded.Size())
+	checkeq(decoded, hmap.Get, t)
+
+	// Decoding needs the equals and hash functions
+	var empty hashmap.Map[string, int]
+	assert.Equal(t, hashmap.ErrMissingFuncs, json.Unmarshal(data, &empty))
+
+	// A map that was never constructed encodes as empty
+	data, err = json.Marshal(&hashmap.Map[string, int]{})
+	assert.NoError(t, err)
+	assert.Equal(t, `[]`, string(data))
+}
+
+func TestHashmapJSONIdenticalKeyEncodings(t *testing.T) {
+	type opaque struct {
+		id int
+	}
+
+	var (
+		equals = func(a, b opaque) bool { return a == b }
+		hash   = func(o opaque) uint64 { return util.HashInt(o.id) }
+	)
+
+	// Keys without exported fields all encode as {}, so the values decide the order
+	small := hashmap.New[opaque, int](1, equals, hash)
+	big := hashmap.New[opaque, int](64, equals, hash)
+
+	for i := 0; i < 5; i++ {
+		small.Put(opaque{i}, 5-i)
+		big.Put(opaque{4 - i}, 1+i)
+	}
+
+	data, err := json.Marshal(small)
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"key":{},"value":1},{"key":{},"value":2},{"key":{},"value":3},{"key":{},"value":4},{"key":{},"value":5}]`, string(data))
+
+	bigData, err := json.Marshal(big)
+	assert.NoError(t, err)
+	assert.Equal(t, string(data), string(bigData))
+}
+
+func TestHashmapGob(t *testing.T) {
+	hmap := hashmap.New[int, string](1, util.Equals[int], util.HashInt)
+	for i := 0; i < 100; i++ {
+		hmap.Put(i, string(rune('a'+i%26)))
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, gob.NewEncoder(&buf).Encode(hmap))
+
+	decoded := hashmap.New[int, string](0, util.Equals[int], util.HashInt)
+	assert.NoError(t, gob.NewDecoder(&buf).Decode(decoded))
+	assert.Equal(t, 100, decoded.Size())
+	checkeq(decoded, hmap.Get, t)
+
+	first, err := hmap.MarshalBinary()
+	assert.NoError(t, err)
+
+	second, err := decoded.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, first, second)
+}
@@ -10,6 +10,11 @@ type Map[K, V any] struct {
 	length   uint64
 	readonly bool
 
+	// iterators is the number of Each calls currently walking over 'entries'.
+	iterators int
+	// generation changes whenever 'entries' is replaced, which tells Each whether the entries it walked over are still in use.
+	generation uint64
+
 	ops ops[K]
 }
 
@@ -79,7 +84,7 @@ func (m *Map[K, V]) resize(newcap uint64) {
 	}
 
 	m.capacity = newm.capacity
-	m.entries = newm.entries
+	m.replaceEntries(newm.entries)
 }
 
 // Put maps the given key to the given value. If the key already exists its
@@ -87,11 +92,8 @@ func (m *Map[K, V]) resize(newcap uint64) {
 func (m *Map[K, V]) Put(key K, val V) {
 	if m.length >= m.capacity/2 {
 		m.resize(m.capacity * 2)
-	} else if m.readonly {
-		entries := make([]entry[K, V], len(m.entries), cap(m.entries))
-		copy(entries, m.entries)
-		m.entries = entries
-		m.readonly = false
+	} else {
+		m.detach()
 	}
 
 	idx := m.getIndex(m.ops.hash(key)) // Possible index
@@ -142,13 +144,7 @@ func (m *Map[K, V]) Remove(key K) {
 		return
 	}
 
-	if m.readonly {
-		entries := make([]entry[K, V], len(m.entries), cap(m.entries))
-		copy(entries, m.entries)
-		m.entries = entries
-		m.readonly = false
-	}
-
+	m.detach()
 	m.remove(idx)
 
 	idx = (idx + 1) & (m.capacity - 1)
@@ -170,6 +166,14 @@ func (m *Map[K, V]) Remove(key K) {
 
 // Clear removes all key-value pairs from the map.
 func (m *Map[K, V]) Clear() {
+	// Entries are shared with a copy or being iterated over, so leave them be and start over
+	if m.readonly || m.iterators > 0 {
+		m.replaceEntries(make([]entry[K, V], m.capacity))
+		m.length = 0
+
+		return
+	}
+
 	for idx, entry := range m.entries {
 		if entry.filled {
 			m.remove(uint64(idx))
@@ -198,15 +202,62 @@ func (m *Map[K, V]) Copy() *Map[K, V] {
 }
 
 // Each calls 'fn' on every key-value pair in the hashmap in no particular
-// order.
+// order. Iteration goes over a snapshot of the map taken when Each was called, so 'fn'
+// is free to Put or Remove; such changes are applied to the map, but are not visited.
+// The first write during iteration copies the entries, same as the first write after Copy.
 func (m *Map[K, V]) Each(fn func(key K, val V)) {
-	for _, ent := range m.entries {
+	entries, generation := m.entries, m.generation
+	m.iterators++
+
+	defer func() {
+		// Writes made during iteration already detached the map from this snapshot
+		if m.generation == generation {
+			m.iterators--
+		}
+	}()
+
+	for _, ent := range entries {
 		if ent.filled {
 			fn(ent.key, ent.value)
 		}
 	}
 }
 
+// RemoveIf removes every key-value pair for which 'pred' returns true in a single pass,
+// and returns the number of removed pairs.
+func (m *Map[K, V]) RemoveIf(pred func(key K, val V) bool) int {
+	var removed int
+
+	m.Each(func(key K, val V) {
+		if pred(key, val) {
+			m.Remove(key)
+			removed++
+		}
+	})
+
+	return removed
+}
+
+// detach gives the map its own copy of the entries if they are shared with a copy of the
+// map or are being iterated over, so that they can be modified safely.
+func (m *Map[K, V]) detach() {
+	if !m.readonly && m.iterators == 0 {
+		return
+	}
+
+	entries := make([]entry[K, V], len(m.entries), cap(m.entries))
+	copy(entries, m.entries)
+	m.replaceEntries(entries)
+}
+
+// replaceEntries swaps in entries that are not shared with anything else.
+func (m *Map[K, V]) replaceEntries(entries []entry[K, V]) {
+	m.entries = entries
+	m.readonly = false
+	m.iterators = 0
+	m.generation++
+}
+
 // getIndex calculates possible index based on hash and hashmap capacity.
 func (m *Map[K, V]) getIndex(hash uint64) uint64 {
 	return hash & (m.capacity - 1)
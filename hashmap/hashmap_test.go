@@ -83,6 +83,47 @@ func TestHashmapCopy(t *testing.T) {
 	}
 }
 
+func TestHashmapCopyClear(t *testing.T) {
+	orig := hashmap.New[int, int](1, util.Equals[int], util.HashInt)
+	for i := 0; i < 10; i++ {
+		orig.Put(i, i)
+	}
+
+	// Clearing a copy leaves the entries it shares with the original alone
+	cpy := orig.Copy()
+	cpy.Clear()
+
+	assert.Equal(t, 0, cpy.Size(), "unexpected copy size after clear")
+	assert.Equal(t, 10, orig.Size(), "original was affected by clear")
+
+	for i := 0; i < 10; i++ {
+		v, ok := orig.Get(i)
+		assert.True(t, ok, "original lost key %d", i)
+		assert.Equal(t, i, v)
+	}
+
+	// Same goes the other way around
+	cpy = orig.Copy()
+	orig.Clear()
+
+	assert.Equal(t, 0, orig.Size(), "unexpected original size after clear")
+	assert.Equal(t, 10, cpy.Size(), "copy was affected by clear")
+
+	v, ok := cpy.Get(5)
+	assert.True(t, ok)
+	assert.Equal(t, 5, v)
+
+	// Both stay usable afterwards
+	orig.Put(1, 100)
+	cpy.Put(1, 200)
+
+	v, _ = orig.Get(1)
+	assert.Equal(t, 100, v)
+
+	v, _ = cpy.Get(1)
+	assert.Equal(t, 200, v)
+}
+
 func TestHashmapFlow(t *testing.T) {
 	hmap := hashmap.New[string, int](1, util.Equals[string], util.HashString)
 
@@ -102,3 +143,72 @@ func TestHashmapFlow(t *testing.T) {
 	fmt.Println(hmap.Get("foo"))
 	fmt.Println(hmap.Get("bar"))
 }
+
+func TestHashmapMutationDuringEach(t *testing.T) {
+	hmap := hashmap.New[int, int](1, util.Equals[int], util.HashInt)
+	for i := 0; i < 100; i++ {
+		hmap.Put(i, i)
+	}
+
+	visited := make(map[int]int)
+
+	// Every original pair is visited exactly once, even though the map keeps changing
+	hmap.Each(func(key, val int) {
+		visited[key]++
+
+		hmap.Remove(key)
+		hmap.Put(key+1000, val)
+	})
+
+	assert.Equal(t, 100, len(visited), "unexpected number of visited keys")
+
+	for key, count := range visited {
+		assert.True(t, key < 100, "key added during iteration was visited")
+		assert.Equal(t, 1, count, "key was visited more than once")
+	}
+
+	assert.Equal(t, 100, hmap.Size(), "unexpected map size after mutating iteration")
+
+	for i := 0; i < 100; i++ {
+		_, ok := hmap.Get(i)
+		assert.False(t, ok, "key was not removed")
+
+		v, ok := hmap.Get(i + 1000)
+		assert.True(t, ok, "key was not added")
+		assert.Equal(t, i, v)
+	}
+}
+
+func TestHashmapEachWithoutEntries(t *testing.T) {
+	var hmap hashmap.Map[int, int]
+
+	hmap.Each(func(key, val int) {
+		t.Fatal("empty map visited a pair")
+	})
+
+	assert.Equal(t, 0, hmap.Size())
+}
+
+func TestHashmapRemoveIf(t *testing.T) {
+	hmap := hashmap.New[int, int](1, util.Equals[int], util.HashInt)
+	for i := 0; i < 100; i++ {
+		hmap.Put(i, i)
+	}
+
+	cpy := hmap.Copy()
+
+	removed := hmap.RemoveIf(func(key, val int) bool {
+		return key%2 == 0
+	})
+
+	assert.Equal(t, 50, removed)
+	assert.Equal(t, 50, hmap.Size(), "unexpected map size after remove")
+
+	hmap.Each(func(key, val int) {
+		assert.True(t, key%2 == 1, "even key was not removed")
+	})
+
+	// Copies are not affected
+	assert.Equal(t, 100, cpy.Size(), "copy was affected by remove")
+	checkeq(hmap, cpy.Get, t)
+}
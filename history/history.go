@@ -0,0 +1,225 @@
+// Package history implements undo/redo on top of stacks, either through commands
+// that know how to undo themselves, or through snapshots of a value.
+package history
+
+import (
+	"fmt"
+
+	"github.com/igorroncevic/go-utils/stack"
+)
+
+var (
+	ErrNothingToUndo         = fmt.Errorf("nothing to undo")
+	ErrNothingToRedo         = fmt.Errorf("nothing to redo")
+	ErrNoTransaction         = fmt.Errorf("no transaction in progress")
+	ErrTransactionInProgress = fmt.Errorf("transaction is in progress")
+)
+
+// Command is a reversible action.
+type Command interface {
+	Do() error
+	Undo() error
+}
+
+// UndoManager executes commands and keeps track of them, so that they can be undone and redone.
+//
+//	m := history.NewUndoManager(100)
+//	err := m.Execute(cmd)
+//	err = m.Undo()
+//	err = m.Redo()
+type UndoManager struct {
+	undo     commandStack[Command]
+	redo     *stack.Stack[Command]
+	maxDepth int
+
+	// transactions holds the transactions that are currently open, the innermost one on top.
+	transactions *stack.Stack[*Transaction]
+}
+
+// Transaction is a group of commands that are undone and redone together.
+type Transaction struct {
+	commands []Command
+}
+
+// NewUndoManager constructs a new undo manager that remembers at most 'maxDepth' commands,
+// forgetting the oldest ones first. If 'maxDepth' is 0, the history is unlimited.
+func NewUndoManager(maxDepth int) *UndoManager {
+	return &UndoManager{
+		undo:         newCommandStack[Command](maxDepth),
+		redo:         stack.New[Command](),
+		maxDepth:     maxDepth,
+		transactions: stack.New[*Transaction](),
+	}
+}
+
+// Execute runs the command and records it. If a transaction is open, the command becomes part of it.
+// Executing a new command clears everything that could have been redone.
+func (m *UndoManager) Execute(cmd Command) error {
+	if err := cmd.Do(); err != nil {
+		return err
+	}
+
+	if tx, err := m.transactions.Peek(); err == nil {
+		(*tx).commands = append((*tx).commands, cmd)
+		return nil
+	}
+
+	m.record(cmd)
+
+	return nil
+}
+
+// Undo reverts the most recently executed command.
+func (m *UndoManager) Undo() error {
+	if !m.transactions.IsEmpty() {
+		return ErrTransactionInProgress
+	}
+
+	cmd, err := m.undo.Pop()
+	if err != nil {
+		return ErrNothingToUndo
+	}
+
+	if err := (*cmd).Undo(); err != nil {
+		_ = m.undo.Push(*cmd)
+		return err
+	}
+
+	m.redo.Push(*cmd)
+
+	return nil
+}
+
+// Redo executes the most recently undone command again.
+func (m *UndoManager) Redo() error {
+	if !m.transactions.IsEmpty() {
+		return ErrTransactionInProgress
+	}
+
+	cmd, err := m.redo.Pop()
+	if err != nil {
+		return ErrNothingToRedo
+	}
+
+	if err := (*cmd).Do(); err != nil {
+		m.redo.Push(*cmd)
+		return err
+	}
+
+	_ = m.undo.Push(*cmd)
+
+	return nil
+}
+
+// CanUndo returns whether there is a command to undo.
+func (m *UndoManager) CanUndo() bool {
+	return m.undo.Size() > 0 && m.transactions.IsEmpty()
+}
+
+// CanRedo returns whether there is a command to redo.
+func (m *UndoManager) CanRedo() bool {
+	return !m.redo.IsEmpty() && m.transactions.IsEmpty()
+}
+
+// Begin opens a transaction, so that all of the commands executed until Commit are undone and
+// redone as one. Transactions can be nested, in which case the inner one becomes part of the outer one.
+func (m *UndoManager) Begin() {
+	m.transactions.Push(&Transaction{})
+}
+
+// Commit closes the innermost transaction and records it as a single command.
+func (m *UndoManager) Commit() error {
+	tx, err := m.transactions.Pop()
+	if err != nil {
+		return ErrNoTransaction
+	}
+
+	if len((*tx).commands) == 0 {
+		return nil
+	}
+
+	if outer, err := m.transactions.Peek(); err == nil {
+		(*outer).commands = append((*outer).commands, *tx)
+		return nil
+	}
+
+	m.record(*tx)
+
+	return nil
+}
+
+// Rollback closes the innermost transaction, undoing all of the commands executed as part of it.
+func (m *UndoManager) Rollback() error {
+	tx, err := m.transactions.Pop()
+	if err != nil {
+		return ErrNoTransaction
+	}
+
+	return (*tx).Undo()
+}
+
+// Clear forgets all of the recorded commands and open transactions.
+func (m *UndoManager) Clear() {
+	m.undo = newCommandStack[Command](m.maxDepth)
+	m.redo = stack.New[Command]()
+	m.transactions = stack.New[*Transaction]()
+}
+
+func (m *UndoManager) record(cmd Command) {
+	_ = m.undo.Push(cmd)
+	m.redo = stack.New[Command]()
+}
+
+// Do executes all of the commands of the transaction in order. If one of them fails,
+// the ones before it are undone.
+func (t *Transaction) Do() error {
+	for i, cmd := range t.commands {
+		if err := cmd.Do(); err != nil {
+			return undoAll(t.commands[:i], err)
+		}
+	}
+
+	return nil
+}
+
+// Undo reverts all of the commands of the transaction in reverse order.
+func (t *Transaction) Undo() error {
+	return undoAll(t.commands, nil)
+}
+
+// undoAll reverts the commands in reverse order, returning 'cause' if they all succeed.
+func undoAll(commands []Command, cause error) error {
+	for i := len(commands) - 1; i >= 0; i-- {
+		if err := commands[i].Undo(); err != nil {
+			return err
+		}
+	}
+
+	return cause
+}
+
+// commandStack is the stack of executed commands, which is bounded or not depending on the maximum depth.
+type commandStack[T any] interface {
+	Push(val T) error
+	Pop() (*T, error)
+	Size() int
+}
+
+// unboundedStack adapts stack.Stack to commandStack.
+type unboundedStack[T any] struct {
+	*stack.Stack[T]
+}
+
+func (s unboundedStack[T]) Push(val T) error {
+	s.Stack.Push(val)
+	return nil
+}
+
+// newCommandStack returns a stack that forgets its bottom elements once it grows over 'maxDepth'.
+func newCommandStack[T any](maxDepth int) commandStack[T] {
+	if maxDepth > 0 {
+		return stack.NewBounded[T](maxDepth, stack.DropOldest)
+	}
+
+	return unboundedStack[T]{stack.New[T]()}
+}
@@ -0,0 +1,149 @@
+package history_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/igorroncevic/go-utils/history"
+)
+
+// add is a command that adds a number to the total, failing if it would go over the limit.
+type add struct {
+	total *int
+	n     int
+	limit int
+}
+
+func (a add) Do() error {
+	if a.limit > 0 && *a.total+a.n > a.limit {
+		return fmt.Errorf("limit exceeded")
+	}
+
+	*a.total += a.n
+
+	return nil
+}
+
+func (a add) Undo() error {
+	*a.total -= a.n
+	return nil
+}
+
+func TestUndoManager(t *testing.T) {
+	var total int
+
+	m := history.NewUndoManager(0)
+	assert.False(t, m.CanUndo())
+	assert.Equal(t, history.ErrNothingToUndo, m.Undo())
+	assert.Equal(t, history.ErrNothingToRedo, m.Redo())
+
+	assert.NoError(t, m.Execute(add{total: &total, n: 1}))
+	assert.NoError(t, m.Execute(add{total: &total, n: 2}))
+	assert.Equal(t, 3, total)
+
+	assert.NoError(t, m.Undo())
+	assert.Equal(t, 1, total)
+	assert.True(t, m.CanRedo())
+
+	assert.NoError(t, m.Redo())
+	assert.Equal(t, 3, total)
+
+	// Executing a new command clears the redo history
+	assert.NoError(t, m.Undo())
+	assert.NoError(t, m.Execute(add{total: &total, n: 10}))
+	assert.Equal(t, 11, total)
+	assert.False(t, m.CanRedo())
+
+	// Failed commands are not recorded
+	assert.Error(t, m.Execute(add{total: &total, n: 100, limit: 50}))
+	assert.NoError(t, m.Undo())
+	assert.NoError(t, m.Undo())
+	assert.Equal(t, 0, total)
+	assert.False(t, m.CanUndo())
+}
+
+func TestUndoManagerMaxDepth(t *testing.T) {
+	var total int
+
+	m := history.NewUndoManager(2)
+
+	for i := 1; i <= 3; i++ {
+		assert.NoError(t, m.Execute(add{total: &total, n: i}))
+	}
+
+	// Only the last two commands are remembered
+	assert.NoError(t, m.Undo())
+	assert.NoError(t, m.Undo())
+	assert.Equal(t, history.ErrNothingToUndo, m.Undo())
+	assert.Equal(t, 1, total)
+}
+
+func TestUndoManagerTransactions(t *testing.T) {
+	var total int
+
+	m := history.NewUndoManager(0)
+	assert.Equal(t, history.ErrNoTransaction, m.Commit())
+
+	m.Begin()
+	assert.NoError(t, m.Execute(add{total: &total, n: 1}))
+
+	m.Begin()
+	assert.NoError(t, m.Execute(add{total: &total, n: 2}))
+	assert.NoError(t, m.Commit())
+
+	assert.NoError(t, m.Execute(add{total: &total, n: 3}))
+	assert.Equal(t, history.ErrTransactionInProgress, m.Undo())
+	assert.NoError(t, m.Commit())
+	assert.Equal(t, 6, total)
+
+	// The whole transaction is undone and redone at once
+	assert.NoError(t, m.Undo())
+	assert.Equal(t, 0, total)
+	assert.False(t, m.CanUndo())
+
+	assert.NoError(t, m.Redo())
+	assert.Equal(t, 6, total)
+
+	// Rollback undoes what was executed in the transaction
+	m.Begin()
+	assert.NoError(t, m.Execute(add{total: &total, n: 4}))
+	assert.NoError(t, m.Rollback())
+	assert.Equal(t, 6, total)
+
+	assert.NoError(t, m.Undo())
+	assert.Equal(t, 0, total)
+}
+
+func TestSnapshots(t *testing.T) {
+	type config struct {
+		Timeout int
+	}
+
+	s := history.NewSnapshots(config{Timeout: 1}, 2)
+	s.Save(config{Timeout: 2})
+	s.Save(config{Timeout: 3})
+	s.Save(config{Timeout: 4})
+
+	state, err := s.Undo()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, state.Timeout)
+
+	state, err = s.Undo()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, state.Timeout)
+
+	// Oldest state was forgotten because of the max depth
+	state, err = s.Undo()
+	assert.Equal(t, history.ErrNothingToUndo, err)
+	assert.Equal(t, 2, state.Timeout)
+
+	state, err = s.Redo()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, state.Timeout)
+	assert.True(t, s.CanRedo())
+
+	s.Save(config{Timeout: 10})
+	assert.False(t, s.CanRedo())
+	assert.Equal(t, 10, s.Current().Timeout)
+}
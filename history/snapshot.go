@@ -0,0 +1,82 @@
+package history
+
+import "github.com/igorroncevic/go-utils/stack"
+
+// Snapshots keeps the history of a value by saving a copy of it on every change, which suits
+// value types that are cheap to copy and have no natural way of undoing a change.
+//
+//	s := history.NewSnapshots(config, 10)
+//	config.Timeout = 5
+//	s.Save(config)
+//	config, err := s.Undo()
+type Snapshots[T any] struct {
+	current  T
+	undo     commandStack[T]
+	redo     *stack.Stack[T]
+	maxDepth int
+}
+
+// NewSnapshots constructs a new history starting with the 'initial' state, which remembers at most
+// 'maxDepth' previous states. If 'maxDepth' is 0, the history is unlimited.
+func NewSnapshots[T any](initial T, maxDepth int) *Snapshots[T] {
+	return &Snapshots[T]{
+		current:  initial,
+		undo:     newCommandStack[T](maxDepth),
+		redo:     stack.New[T](),
+		maxDepth: maxDepth,
+	}
+}
+
+// Current returns the current state.
+func (s *Snapshots[T]) Current() T {
+	return s.current
+}
+
+// Save makes 'state' the current state, clearing everything that could have been redone.
+func (s *Snapshots[T]) Save(state T) {
+	_ = s.undo.Push(s.current)
+	s.current = state
+	s.redo = stack.New[T]()
+}
+
+// Undo goes back to the previous state and returns it.
+func (s *Snapshots[T]) Undo() (T, error) {
+	previous, err := s.undo.Pop()
+	if err != nil {
+		return s.current, ErrNothingToUndo
+	}
+
+	s.redo.Push(s.current)
+	s.current = *previous
+
+	return s.current, nil
+}
+
+// Redo goes forward to the most recently undone state and returns it.
+func (s *Snapshots[T]) Redo() (T, error) {
+	next, err := s.redo.Pop()
+	if err != nil {
+		return s.current, ErrNothingToRedo
+	}
+
+	_ = s.undo.Push(s.current)
+	s.current = *next
+
+	return s.current, nil
+}
+
+// CanUndo returns whether there is a previous state.
+func (s *Snapshots[T]) CanUndo() bool {
+	return s.undo.Size() > 0
+}
+
+// CanRedo returns whether there is an undone state.
+func (s *Snapshots[T]) CanRedo() bool {
+	return !s.redo.IsEmpty()
+}
+
+// Clear forgets all of the previous and undone states, keeping the current one.
+func (s *Snapshots[T]) Clear() {
+	s.undo = newCommandStack[T](s.maxDepth)
+	s.redo = stack.New[T]()
+}
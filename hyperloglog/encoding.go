@@ -0,0 +1,83 @@
+package hyperloglog
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+const (
+	kindSparse = iota
+	kindDense
+)
+
+var (
+	ErrMissingFuncs = fmt.Errorf("sketch has no hash function, construct it with New before decoding")
+	ErrInvalidData  = fmt.Errorf("invalid hyperloglog data")
+)
+
+// MarshalBinary encodes the precision of the sketch, followed by either its sparse entries or all of its registers.
+// The hash function is not encoded, so the decoding sketch must use the same one.
+func (s *Sketch[T]) MarshalBinary() ([]byte, error) {
+	if s.registers != nil {
+		return append([]byte{s.precision, kindDense}, s.registers...), nil
+	}
+
+	data := make([]byte, 2, 2+4*len(s.sparse))
+	data[0], data[1] = s.precision, kindSparse
+
+	for _, entry := range s.sparse {
+		data = binary.LittleEndian.AppendUint32(data, entry)
+	}
+
+	return data, nil
+}
+
+// UnmarshalBinary replaces the sketch with the one encoded by MarshalBinary.
+// The sketch must be constructed with New beforehand, so that it knows how to hash values.
+func (s *Sketch[T]) UnmarshalBinary(data []byte) error {
+	if s.hash == nil {
+		return ErrMissingFuncs
+	}
+
+	if len(data) < 2 || data[0] < MinPrecision || data[0] > MaxPrecision {
+		return ErrInvalidData
+	}
+
+	var (
+		decoded = &Sketch[T]{precision: data[0], hash: s.hash}
+		body    = data[2:]
+	)
+
+	switch data[1] {
+	case kindDense:
+		if len(body) != decoded.registerCount() {
+			return ErrInvalidData
+		}
+
+		decoded.registers = make([]uint8, len(body))
+		copy(decoded.registers, body)
+	case kindSparse:
+		if len(body)%4 != 0 {
+			return ErrInvalidData
+		}
+
+		decoded.sparse = make([]uint32, len(body)/4)
+
+		for i := range decoded.sparse {
+			entry := binary.LittleEndian.Uint32(body[i*4:])
+
+			// Entries must be sorted by their index, which must be in range
+			if int(entry>>8) >= decoded.registerCount() || i > 0 && entry>>8 <= decoded.sparse[i-1]>>8 {
+				return ErrInvalidData
+			}
+
+			decoded.sparse[i] = entry
+		}
+	default:
+		return ErrInvalidData
+	}
+
+	*s = *decoded
+
+	return nil
+}
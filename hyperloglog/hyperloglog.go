@@ -0,0 +1,204 @@
+// Package hyperloglog implements HyperLogLog, a sketch that estimates the number of distinct values
+// in a stream using a fixed, small amount of memory. See "HyperLogLog: the analysis of a
+// near-optimal cardinality estimation algorithm" by Flajolet et al.
+package hyperloglog
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"sort"
+
+	"github.com/igorroncevic/go-utils/util"
+)
+
+const (
+	MinPrecision = 4
+	MaxPrecision = 18
+)
+
+var (
+	ErrIncompatible = fmt.Errorf("sketches have different precisions")
+)
+
+// Sketch estimates the number of distinct values added to it. It keeps 2^precision registers,
+// and its standard error is about 1.04 / sqrt(2^precision), e.g. 0.8% at precision 14.
+//
+// Small cardinalities are kept in a sparse list of the registers that are set, which is
+// replaced by the full array of registers once it would stop saving memory.
+type Sketch[T any] struct {
+	precision uint8
+	// registers is nil while the sketch is sparse.
+	registers []uint8
+	// sparse holds the set registers sorted by their index, each one encoded as index<<8 | value.
+	sparse []uint32
+
+	hash util.HashFn[T]
+}
+
+// New constructs a new, empty sketch with the given precision, clamped between MinPrecision and MaxPrecision.
+// Values are hashed with 'hash', e.g. util.HashString, the same as in hashmap.Map.
+func New[T any](precision uint8, hash util.HashFn[T]) *Sketch[T] {
+	return &Sketch[T]{
+		precision: util.Clamp(precision, MinPrecision, MaxPrecision),
+		hash:      hash,
+	}
+}
+
+// Add adds the value to the sketch.
+func (s *Sketch[T]) Add(val T) {
+	// The hash is mixed once more, since the upper bits of some hashes, like FNV, are poorly distributed
+	hash := util.HashUint64(s.hash(val))
+
+	// The first bits pick the register, which keeps the length of the longest run of leading zeros in the rest of them.
+	// The last bit is set, so that the run is never longer than the bits that are left.
+	idx := uint32(hash >> (64 - s.precision))
+	rest := hash<<s.precision | 1<<(s.precision-1)
+
+	s.set(idx, uint8(bits.LeadingZeros64(rest)+1))
+}
+
+// Estimate returns the estimated number of distinct values added to the sketch.
+func (s *Sketch[T]) Estimate() uint64 {
+	m := float64(s.registerCount())
+
+	if s.registers == nil {
+		return uint64(math.Round(linearCounting(m, m-float64(len(s.sparse)))))
+	}
+
+	var (
+		sum   float64
+		zeros int
+	)
+
+	for _, val := range s.registers {
+		sum += 1 / float64(uint64(1)<<val)
+
+		if val == 0 {
+			zeros++
+		}
+	}
+
+	estimate := alpha(m) * m * m / sum
+
+	// Small cardinalities are estimated better by counting the registers that are still empty
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = linearCounting(m, float64(zeros))
+	}
+
+	return uint64(math.Round(estimate))
+}
+
+// Merge adds all of the values of 'other' to this sketch. Both sketches must have
+// the same precision, and should use the same hash function.
+func (s *Sketch[T]) Merge(other *Sketch[T]) error {
+	if s.precision != other.precision {
+		return ErrIncompatible
+	}
+
+	if other.registers == nil {
+		for _, entry := range other.sparse {
+			s.set(entry>>8, uint8(entry))
+		}
+
+		return nil
+	}
+
+	s.toDense()
+
+	for idx, val := range other.registers {
+		if val > s.registers[idx] {
+			s.registers[idx] = val
+		}
+	}
+
+	return nil
+}
+
+// Precision returns the precision of the sketch.
+func (s *Sketch[T]) Precision() uint8 {
+	return s.precision
+}
+
+// Clear removes all values from the sketch, making it sparse again.
+func (s *Sketch[T]) Clear() {
+	s.registers = nil
+	s.sparse = nil
+}
+
+// Copy returns a copy of this sketch.
+func (s *Sketch[T]) Copy() *Sketch[T] {
+	copy := New(s.precision, s.hash)
+	_ = copy.Merge(s)
+
+	return copy
+}
+
+// set raises the register to 'val', unless it already holds a larger one.
+func (s *Sketch[T]) set(idx uint32, val uint8) {
+	if s.registers != nil {
+		if val > s.registers[idx] {
+			s.registers[idx] = val
+		}
+
+		return
+	}
+
+	i := sort.Search(len(s.sparse), func(i int) bool {
+		return s.sparse[i]>>8 >= idx
+	})
+
+	if i < len(s.sparse) && s.sparse[i]>>8 == idx {
+		if val > uint8(s.sparse[i]) {
+			s.sparse[i] = idx<<8 | uint32(val)
+		}
+
+		return
+	}
+
+	s.sparse = append(s.sparse, 0)
+	copy(s.sparse[i+1:], s.sparse[i:])
+	s.sparse[i] = idx<<8 | uint32(val)
+
+	// Sparse entries take 4 bytes, while registers take 1
+	if len(s.sparse)*4 > s.registerCount() {
+		s.toDense()
+	}
+}
+
+// toDense replaces the sparse list with the full array of registers.
+func (s *Sketch[T]) toDense() {
+	if s.registers != nil {
+		return
+	}
+
+	s.registers = make([]uint8, s.registerCount())
+	for _, entry := range s.sparse {
+		s.registers[entry>>8] = uint8(entry)
+	}
+
+	s.sparse = nil
+}
+
+func (s *Sketch[T]) registerCount() int {
+	return 1 << s.precision
+}
+
+// alpha corrects the bias of the raw estimate for 'm' registers.
+func alpha(m float64) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	default:
+		return 0.7213 / (1 + 1.079/m)
+	}
+}
+
+// linearCounting estimates the cardinality from the number of registers that are still empty.
+func linearCounting(m, empty float64) float64 {
+	return m * math.Log(m/empty)
+}
@@ -0,0 +1,94 @@
+package hyperloglog_test
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/igorroncevic/go-utils/hyperloglog"
+	"github.com/igorroncevic/go-utils/util"
+)
+
+func relativeError(estimate uint64, actual int) float64 {
+	return math.Abs(float64(estimate)-float64(actual)) / float64(actual)
+}
+
+func TestSketch(t *testing.T) {
+	for _, count := range []int{10, 1000, 100000, 1000000} {
+		s := hyperloglog.New(14, util.HashInt)
+
+		for i := 0; i < count; i++ {
+			s.Add(i)
+			s.Add(i) // duplicates are not counted
+		}
+
+		assert.True(t, relativeError(s.Estimate(), count) < 0.03, "count %d, estimate %d", count, s.Estimate())
+	}
+}
+
+func TestSketchStrings(t *testing.T) {
+	s := hyperloglog.New(12, util.HashString)
+
+	for i := 0; i < 50000; i++ {
+		s.Add("user-" + strconv.Itoa(i))
+	}
+
+	assert.True(t, relativeError(s.Estimate(), 50000) < 0.05)
+	assert.Equal(t, uint8(12), s.Precision())
+
+	s.Clear()
+	assert.Equal(t, uint64(0), s.Estimate())
+
+	assert.Equal(t, uint8(hyperloglog.MaxPrecision), hyperloglog.New(30, util.HashString).Precision())
+}
+
+func TestSketchMerge(t *testing.T) {
+	a := hyperloglog.New(14, util.HashInt)
+	b := hyperloglog.New(14, util.HashInt)
+	sparse := hyperloglog.New(14, util.HashInt)
+
+	for i := 0; i < 60000; i++ {
+		a.Add(i)
+		b.Add(i + 30000)
+	}
+
+	for i := 0; i < 100; i++ {
+		sparse.Add(i + 1000000)
+	}
+
+	copy := a.Copy()
+
+	assert.NoError(t, a.Merge(b))
+	assert.NoError(t, a.Merge(sparse))
+	assert.True(t, relativeError(a.Estimate(), 90100) < 0.03)
+	assert.True(t, relativeError(copy.Estimate(), 60000) < 0.03)
+
+	// A sparse sketch becomes dense when merged with a dense one
+	assert.NoError(t, sparse.Merge(b))
+	assert.True(t, relativeError(sparse.Estimate(), 60100) < 0.03)
+
+	assert.Equal(t, hyperloglog.ErrIncompatible, a.Merge(hyperloglog.New(10, util.HashInt)))
+}
+
+func TestSketchBinary(t *testing.T) {
+	for _, count := range []int{100, 100000} {
+		s := hyperloglog.New(14, util.HashInt)
+		for i := 0; i < count; i++ {
+			s.Add(i)
+		}
+
+		data, err := s.MarshalBinary()
+		assert.NoError(t, err)
+
+		decoded := hyperloglog.New(4, util.HashInt)
+		assert.NoError(t, decoded.UnmarshalBinary(data))
+		assert.Equal(t, s.Estimate(), decoded.Estimate())
+		assert.Equal(t, uint8(14), decoded.Precision())
+
+		assert.Equal(t, hyperloglog.ErrInvalidData, decoded.UnmarshalBinary(data[:len(data)-1]))
+		assert.Equal(t, s.Estimate(), decoded.Estimate())
+	}
+
+	assert.Equal(t, hyperloglog.ErrMissingFuncs, (&hyperloglog.Sketch[int]{}).UnmarshalBinary([]byte{14, 0}))
+}
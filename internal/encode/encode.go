@@ -0,0 +1,73 @@
+// Package encode holds the helpers shared by the containers that encode themselves
+// in a deterministic order.
+package encode
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"sort"
+)
+
+// Gob encodes a single value with gob.
+func Gob(val any) ([]byte, error) {
+	var buf bytes.Buffer
+
+	if err := gob.NewEncoder(&buf).Encode(val); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// JSON encodes a single value as JSON.
+func JSON(val any) ([]byte, error) {
+	return json.Marshal(val)
+}
+
+// SortByEncoding sorts the items by the encoding of their parts, e.g. the key and the value of a map entry.
+// Later parts are only compared when the earlier ones encode the same, and items whose parts all encode
+// the same keep their order, so that they are indistinguishable in the output anyway.
+func SortByEncoding[T any](items []T, encode func(val any) ([]byte, error), parts func(item T) []any) error {
+	encoded := make([][][]byte, len(items))
+
+	for i, item := range items {
+		for _, part := range parts(item) {
+			enc, err := encode(part)
+			if err != nil {
+				return err
+			}
+
+			encoded[i] = append(encoded[i], enc)
+		}
+	}
+
+	sort.Stable(byEncoding[T]{items, encoded})
+
+	return nil
+}
+
+// byEncoding sorts items alongside the encodings of their parts.
+type byEncoding[T any] struct {
+	items   []T
+	encoded [][][]byte
+}
+
+func (b byEncoding[T]) Len() int {
+	return len(b.items)
+}
+
+func (b byEncoding[T]) Less(i, j int) bool {
+	for part := range b.encoded[i] {
+		if cmp := bytes.Compare(b.encoded[i][part], b.encoded[j][part]); cmp != 0 {
+			return cmp < 0
+		}
+	}
+
+	return false
+}
+
+func (b byEncoding[T]) Swap(i, j int) {
+	b.items[i], b.items[j] = b.items[j], b.items[i]
+	b.encoded[i], b.encoded[j] = b.encoded[j], b.encoded[i]
+}
@@ -0,0 +1,47 @@
+package encode_test
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/igorroncevic/go-utils/internal/encode"
+)
+
+type pair struct {
+	key, val any
+}
+
+func parts(p pair) []any {
+	return []any{p.key, p.val}
+}
+
+func TestSortByEncoding(t *testing.T) {
+	items := []pair{{"b", 1}, {"a", 2}, {"c", 0}}
+
+	assert.NoError(t, encode.SortByEncoding(items, encode.JSON, parts))
+	assert.Equal(t, []pair{{"a", 2}, {"b", 1}, {"c", 0}}, items)
+}
+
+func TestSortByEncodingTies(t *testing.T) {
+	type opaque struct {
+		id int
+	}
+
+	// Keys without exported fields all encode as {}, so the values decide the order
+	items := []pair{{opaque{1}, 3}, {opaque{2}, 1}, {opaque{3}, 2}}
+
+	assert.NoError(t, encode.SortByEncoding(items, encode.JSON, parts))
+	assert.Equal(t, []pair{{opaque{2}, 1}, {opaque{3}, 2}, {opaque{1}, 3}}, items)
+
+	// Items that encode the same keep their order
+	items = []pair{{opaque{1}, 0}, {opaque{2}, 0}, {opaque{3}, 0}}
+
+	assert.NoError(t, encode.SortByEncoding(items, encode.JSON, parts))
+	assert.Equal(t, []pair{{opaque{1}, 0}, {opaque{2}, 0}, {opaque{3}, 0}}, items)
+}
+
+func TestSortByEncodingError(t *testing.T) {
+	items := []pair{{"a", make(chan int)}}
+
+	assert.Error(t, encode.SortByEncoding(items, encode.JSON, parts))
+}
@@ -0,0 +1,113 @@
+package list
+
+// chain holds the nodes and the bookkeeping shared by all of the lists in this package.
+type chain[T any] struct {
+	Head, Tail *Node[T]
+
+	length int
+	owner  *owner
+}
+
+// owner identifies the list that nodes belong to, so that nodes that were removed or
+// belong to another list can be told apart. It is not empty, since pointers to distinct
+// zero-size values may be equal.
+type owner struct {
+	_ byte
+}
+
+// Size returns the number of elements in the list.
+func (l *chain[T]) Size() int {
+	return l.length
+}
+
+// Clear clears the list completely.
+func (l *chain[T]) Clear() {
+	l.Head = nil
+	l.Tail = nil
+	l.length = 0
+
+	// A new owner disowns all of the nodes at once
+	l.owner = nil
+}
+
+// ToSlice returns a slice representation of the list.
+func (l *chain[T]) ToSlice() []T {
+	sliced := make([]T, 0, l.length)
+
+	l.Head.Each(func(n *Node[T]) bool {
+		sliced = append(sliced, n.Value)
+		return false
+	})
+
+	return sliced
+}
+
+// insertAfter links 'node' right after 'mark'. If 'mark' is nil, 'node' becomes the new head.
+func (l *chain[T]) insertAfter(mark, node *Node[T]) {
+	// 1. mark <-> next
+	// 2. insert node
+	// 	- 2.a. mark.Next ----> node,
+	// 	- 2.b. node.Next ----> next
+	// 	- 2.c. mark <--------- node.Prev,
+	// 	- 2.d. node <--------- next.Prev
+	// result: mark <-> node <-> next
+	var next *Node[T]
+
+	if mark == nil {
+		next = l.Head
+		l.Head = node
+	} else {
+		next = mark.Next
+		mark.Next = node
+	}
+
+	node.Prev = mark
+	node.Next = next
+	node.owner = l.currentOwner()
+
+	if next == nil {
+		l.Tail = node
+	} else {
+		next.Prev = node
+	}
+
+	l.length++
+}
+
+// unlink removes 'node' from the list.
+func (l *chain[T]) unlink(node *Node[T]) {
+	// 1. prev <-> node <-> next
+	// 2. remove node
+	// 	- 2.a. prev -> next ===== node.Prev.Next -> node.Next
+	// 	- 2.b. prev <- next ===== node.Next.Prev -> node.Prev
+	if node.Prev == nil {
+		l.Head = node.Next
+	} else {
+		node.Prev.Next = node.Next
+	}
+
+	if node.Next == nil {
+		l.Tail = node.Prev
+	} else {
+		node.Next.Prev = node.Prev
+	}
+
+	node.Next = nil
+	node.Prev = nil
+	node.owner = nil
+	l.length--
+}
+
+// owns returns whether 'node' is one of the nodes of this list.
+func (l *chain[T]) owns(node *Node[T]) bool {
+	return node != nil && node.owner != nil && node.owner == l.owner
+}
+
+// currentOwner returns the owner of the list's nodes, creating it on first use.
+func (l *chain[T]) currentOwner() *owner {
+	if l.owner == nil {
+		l.owner = &owner{}
+	}
+
+	return l.owner
+}
@@ -0,0 +1,143 @@
+package list
+
+// Cursor is a bidirectional iterator over a sorted List, which allows removing nodes while iterating.
+//
+// A new cursor starts off the list. From there, Next moves it to the head and Prev to the tail,
+// and moving past either end of the list takes it off the list again.
+//
+//	c := l.Cursor()
+//	for c.Next() {
+//		if c.Value() < 0 {
+//			c.Remove()
+//		}
+//	}
+type Cursor[T any] struct {
+	cursor[T]
+
+	list *List[T]
+}
+
+// DoublyLinkedCursor is a bidirectional iterator over a DoublyLinked list, which also allows
+// inserting values at its position.
+type DoublyLinkedCursor[T any] struct {
+	cursor[T]
+
+	list *DoublyLinked[T]
+}
+
+// cursor holds the behaviour shared by the cursors of all of the lists in this package.
+type cursor[T any] struct {
+	chain *chain[T]
+	node  *Node[T]
+
+	// prev and next are the neighbours of the node removed through the cursor,
+	// which are where the iteration continues from.
+	prev, next *Node[T]
+	removed    bool
+}
+
+// Cursor returns a new cursor over the list, positioned off the list.
+func (l *List[T]) Cursor() *Cursor[T] {
+	return &Cursor[T]{
+		cursor: cursor[T]{chain: &l.chain},
+		list:   l,
+	}
+}
+
+// Cursor returns a new cursor over the list, positioned off the list.
+func (l *DoublyLinked[T]) Cursor() *DoublyLinkedCursor[T] {
+	return &DoublyLinkedCursor[T]{
+		cursor: cursor[T]{chain: &l.chain},
+		list:   l,
+	}
+}
+
+// Seek moves the cursor to the first node whose value is not less than 'val',
+// and returns false if there is no such node, leaving the cursor off the list.
+func (c *Cursor[T]) Seek(val T) bool {
+	node := c.list.Head
+	for node != nil && c.list.isBefore(node.Value, val) {
+		node = node.Next
+	}
+
+	c.moveTo(node)
+
+	return c.node != nil
+}
+
+// InsertHere inserts the value right before the cursor's node, or at the back of the list
+// when the cursor is off the list, which includes being on a node that has since been removed
+// from the list. The cursor stays where it is, so the new value is visited by moving backwards.
+func (c *DoublyLinkedCursor[T]) InsertHere(val T) {
+	node := &Node[T]{Value: val}
+
+	switch {
+	case c.removed:
+		c.list.insertAfter(c.prev, node)
+		c.prev = node
+	case !c.chain.owns(c.node):
+		c.list.insertAfter(c.list.Tail, node)
+	default:
+		c.list.insertAfter(c.node.Prev, node)
+	}
+}
+
+// Next moves the cursor forward and returns whether it is on a node.
+func (c *cursor[T]) Next() bool {
+	switch {
+	case c.removed:
+		c.moveTo(c.next)
+	case c.node == nil:
+		c.moveTo(c.chain.Head)
+	default:
+		c.moveTo(c.node.Next)
+	}
+
+	return c.node != nil
+}
+
+// Prev moves the cursor backwards and returns whether it is on a node.
+func (c *cursor[T]) Prev() bool {
+	switch {
+	case c.removed:
+		c.moveTo(c.prev)
+	case c.node == nil:
+		c.moveTo(c.chain.Tail)
+	default:
+		c.moveTo(c.node.Prev)
+	}
+
+	return c.node != nil
+}
+
+// Value returns the value of the cursor's node, or the zero value if the cursor is off the list.
+func (c *cursor[T]) Value() T {
+	if c.node == nil {
+		var empty T
+		return empty
+	}
+
+	return c.node.Value
+}
+
+// Remove removes the cursor's node from the list and returns whether there was one to remove.
+// Following calls to Next and Prev continue from the removed node's neighbours.
+func (c *cursor[T]) Remove() bool {
+	// The node may have been removed from the list since the cursor moved onto it
+	if !c.chain.owns(c.node) {
+		return false
+	}
+
+	c.prev, c.next = c.node.Prev, c.node.Next
+	c.chain.unlink(c.node)
+	c.node = nil
+	c.removed = true
+
+	return true
+}
+
+func (c *cursor[T]) moveTo(node *Node[T]) {
+	c.node = node
+	c.prev, c.next = nil, nil
+	c.removed = false
+}
@@ -0,0 +1,93 @@
+package list_test
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/igorroncevic/go-utils/list"
+)
+
+func TestListCursor(t *testing.T) {
+	l := fromSlice(t, []int{1, 2, 3, 4, 5, 6})
+
+	// Remove even values while iterating
+	c := l.Cursor()
+	for c.Next() {
+		if c.Value()%2 == 0 {
+			assert.True(t, c.Remove())
+			assert.False(t, c.Remove(), "node was removed twice")
+		}
+	}
+
+	assert.EqualValues(t, []int{1, 3, 5}, l.ToSlice())
+	assert.Equal(t, 3, l.Size(), "unexpected list size after removal")
+
+	// Iterate backwards from the tail
+	reversed := []int{}
+	for c.Prev() {
+		reversed = append(reversed, c.Value())
+	}
+
+	assert.EqualValues(t, []int{5, 3, 1}, reversed)
+
+	// Seek to the first value not less than the given one
+	assert.True(t, c.Seek(2))
+	assert.Equal(t, 3, c.Value())
+	assert.True(t, c.Seek(5))
+	assert.Equal(t, 5, c.Value())
+	assert.False(t, c.Seek(6))
+	assert.Equal(t, 0, c.Value())
+
+	// Removing, then moving back
+	c.Seek(3)
+	c.Remove()
+	assert.True(t, c.Prev())
+	assert.Equal(t, 1, c.Value())
+}
+
+func TestDoublyLinkedCursor(t *testing.T) {
+	l := list.NewDoublyLinked[string]()
+	l.PushBack("a")
+	l.PushBack("c")
+
+	c := l.Cursor()
+	assert.True(t, c.Next())
+	assert.True(t, c.Next())
+	assert.Equal(t, "c", c.Value())
+
+	// Insert before the current node
+	c.InsertHere("b")
+	assert.EqualValues(t, []string{"a", "b", "c"}, l.ToSlice())
+	assert.Equal(t, "c", c.Value())
+
+	// Insert in place of the removed node
+	c.Remove()
+	c.InsertHere("x")
+	assert.EqualValues(t, []string{"a", "b", "x"}, l.ToSlice())
+	assert.True(t, c.Prev())
+	assert.Equal(t, "x", c.Value())
+
+	// Insert at the back when off the list
+	assert.False(t, c.Next())
+	c.InsertHere("z")
+	assert.EqualValues(t, []string{"a", "b", "x", "z"}, l.ToSlice())
+	assert.Equal(t, 4, l.Size(), "unexpected list size")
+}
+
+func TestDoublyLinkedCursorStaleNode(t *testing.T) {
+	l := list.NewDoublyLinked[int]()
+	l.PushBack(1)
+	n2 := l.PushBack(2)
+	l.PushBack(3)
+
+	c := l.Cursor()
+	assert.True(t, c.Next())
+	assert.True(t, c.Next())
+	assert.Equal(t, 2, c.Value())
+
+	// A node removed through the list leaves the cursor off the list
+	l.Remove(n2)
+	c.InsertHere(99)
+	assert.EqualValues(t, []int{1, 3, 99}, l.ToSlice())
+	assert.Equal(t, 3, l.Size(), "unexpected list size")
+}
@@ -0,0 +1,129 @@
+package list
+
+// DoublyLinked is an implementation of a general purpose doubly linked-list, which keeps
+// values in the order they were inserted in. Duplicates are allowed.
+//
+// Methods that take a node do nothing if it does not belong to this list, e.g. if it was already
+// removed or belongs to another list.
+type DoublyLinked[T any] struct {
+	chain[T]
+}
+
+func NewDoublyLinked[T any]() *DoublyLinked[T] {
+	return &DoublyLinked[T]{}
+}
+
+// PushFront adds a value to the front of the list and returns its node.
+func (l *DoublyLinked[T]) PushFront(val T) *Node[T] {
+	node := &Node[T]{Value: val}
+	l.insertAfter(nil, node)
+
+	return node
+}
+
+// PushBack adds a value to the back of the list and returns its node.
+func (l *DoublyLinked[T]) PushBack(val T) *Node[T] {
+	node := &Node[T]{Value: val}
+	l.insertAfter(l.Tail, node)
+
+	return node
+}
+
+// InsertBefore adds a value right before the 'mark' node and returns its node,
+// or returns nil if 'mark' does not belong to the list.
+func (l *DoublyLinked[T]) InsertBefore(val T, mark *Node[T]) *Node[T] {
+	if !l.owns(mark) {
+		return nil
+	}
+
+	node := &Node[T]{Value: val}
+	l.insertAfter(mark.Prev, node)
+
+	return node
+}
+
+// InsertAfter adds a value right after the 'mark' node and returns its node,
+// or returns nil if 'mark' does not belong to the list.
+func (l *DoublyLinked[T]) InsertAfter(val T, mark *Node[T]) *Node[T] {
+	if !l.owns(mark) {
+		return nil
+	}
+
+	node := &Node[T]{Value: val}
+	l.insertAfter(mark, node)
+
+	return node
+}
+
+// Remove removes the node from the list in O(1) and returns its value.
+func (l *DoublyLinked[T]) Remove(node *Node[T]) T {
+	if node == nil {
+		var empty T
+		return empty
+	}
+
+	if l.owns(node) {
+		l.unlink(node)
+	}
+
+	return node.Value
+}
+
+// MoveToFront moves the node to the front of the list.
+func (l *DoublyLinked[T]) MoveToFront(node *Node[T]) {
+	if l.Head == node || !l.owns(node) {
+		return
+	}
+
+	l.unlink(node)
+	l.insertAfter(nil, node)
+}
+
+// MoveToBack moves the node to the back of the list.
+func (l *DoublyLinked[T]) MoveToBack(node *Node[T]) {
+	if l.Tail == node || !l.owns(node) {
+		return
+	}
+
+	l.unlink(node)
+	l.insertAfter(l.Tail, node)
+}
+
+// Splice moves all of the nodes of 'other' to the back of this list, leaving 'other' empty.
+// The nodes are relinked in O(1), but each one of them has to be handed over to this list, which is O(m).
+func (l *DoublyLinked[T]) Splice(other *DoublyLinked[T]) {
+	if other == l || other.Head == nil {
+		return
+	}
+
+	owner := l.currentOwner()
+
+	other.Head.Each(func(n *Node[T]) bool {
+		n.owner = owner
+		return false
+	})
+
+	if l.Tail == nil {
+		l.Head = other.Head
+	} else {
+		l.Tail.Next = other.Head
+		other.Head.Prev = l.Tail
+	}
+
+	l.Tail = other.Tail
+	l.length += other.length
+
+	other.Clear()
+}
+
+// Copy creates a new list with same values as the original.
+func (l *DoublyLinked[T]) Copy() *DoublyLinked[T] {
+	copy := NewDoublyLinked[T]()
+
+	l.Head.Each(func(n *Node[T]) bool {
+		copy.PushBack(n.Value)
+		return false
+	})
+
+	return copy
+}
@@ -0,0 +1,99 @@
+package list_test
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/igorroncevic/go-utils/list"
+)
+
+func TestDoublyLinked(t *testing.T) {
+	linkedList := list.NewDoublyLinked[int]()
+
+	two := linkedList.PushBack(2)
+	linkedList.PushFront(1)
+	four := linkedList.PushBack(4)
+	linkedList.InsertAfter(3, two)
+	linkedList.InsertBefore(0, linkedList.Head)
+	linkedList.PushBack(2)
+
+	assert.Equal(t, 6, linkedList.Size(), "unexpected list size")
+	assert.EqualValues(t, []int{0, 1, 2, 3, 4, 2}, linkedList.ToSlice(), "unexpected slice values")
+
+	// Remove by node
+	assert.Equal(t, 4, linkedList.Remove(four))
+	assert.EqualValues(t, []int{0, 1, 2, 3, 2}, linkedList.ToSlice(), "unexpected slice values after remove")
+
+	// Move nodes around
+	linkedList.MoveToFront(two)
+	assert.EqualValues(t, []int{2, 0, 1, 3, 2}, linkedList.ToSlice(), "unexpected slice values after move to front")
+
+	linkedList.MoveToBack(two)
+	assert.EqualValues(t, []int{0, 1, 3, 2, 2}, linkedList.ToSlice(), "unexpected slice values after move to back")
+	assert.True(t, linkedList.Tail == two, "moved node is not the tail")
+
+	// Splice another list
+	other := list.NewDoublyLinked[int]()
+	other.PushBack(7)
+	other.PushBack(8)
+
+	linkedList.Splice(other)
+	assert.EqualValues(t, []int{0, 1, 3, 2, 2, 7, 8}, linkedList.ToSlice(), "unexpected slice values after splice")
+	assert.Equal(t, 7, linkedList.Size(), "unexpected list size after splice")
+	assert.Equal(t, 0, other.Size(), "spliced list is not empty")
+	assert.Nil(t, other.Head)
+
+	// Copies are independent
+	cpy := linkedList.Copy()
+	cpy.Clear()
+	assert.Equal(t, 0, cpy.Size(), "unexpected list size after clear")
+	assert.Equal(t, 7, linkedList.Size(), "original list was affected by clear")
+}
+
+func TestDoublyLinkedForeignNodes(t *testing.T) {
+	linkedList := list.NewDoublyLinked[int]()
+	one := linkedList.PushBack(1)
+	linkedList.PushBack(2)
+
+	// Removing the same node twice only removes it once
+	assert.Equal(t, 1, linkedList.Remove(one))
+	assert.Equal(t, 1, linkedList.Remove(one))
+	assert.EqualValues(t, []int{2}, linkedList.ToSlice())
+	assert.Equal(t, 1, linkedList.Size(), "unexpected list size after double remove")
+
+	// Removed nodes can no longer be used as marks or moved
+	assert.Nil(t, linkedList.InsertBefore(0, one))
+	assert.Nil(t, linkedList.InsertAfter(0, one))
+	linkedList.MoveToFront(one)
+	linkedList.MoveToBack(one)
+	assert.EqualValues(t, []int{2}, linkedList.ToSlice())
+
+	// Nodes of other lists, including sorted ones, are left alone
+	other := list.NewDoublyLinked[int]()
+	foreign := other.PushBack(3)
+	linkedList.Remove(foreign)
+	linkedList.MoveToFront(foreign)
+	assert.Nil(t, linkedList.InsertAfter(4, foreign))
+	assert.EqualValues(t, []int{3}, other.ToSlice())
+	assert.Equal(t, 1, linkedList.Size(), "foreign node changed the list size")
+
+	sorted := list.New[int](lessFn, equalFn)
+	assert.NoError(t, sorted.Push(5))
+	linkedList.Remove(sorted.Head)
+	assert.Equal(t, 1, sorted.Size())
+	assert.Equal(t, 1, linkedList.Size())
+
+	// Nodes of a cleared list no longer belong to it
+	two := linkedList.Head
+	linkedList.Clear()
+	linkedList.PushBack(6)
+	linkedList.Remove(two)
+	assert.EqualValues(t, []int{6}, linkedList.ToSlice())
+
+	// Spliced nodes belong to the list they were spliced into
+	linkedList.Splice(other)
+	other.Remove(foreign)
+	assert.EqualValues(t, []int{6, 3}, linkedList.ToSlice())
+	assert.Equal(t, 3, linkedList.Remove(foreign))
+	assert.EqualValues(t, []int{6}, linkedList.ToSlice())
+}
@@ -0,0 +1,81 @@
+package list
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+
+	"github.com/igorroncevic/go-utils/internal/encode"
+)
+
+var (
+	ErrMissingFuncs = fmt.Errorf("list has no less or equals function, construct it with New before decoding")
+)
+
+// MarshalJSON encodes the list as an array of its values, in the list's order.
+func (l *List[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(l.ToSlice())
+}
+
+// UnmarshalJSON replaces the contents of the list with the decoded values.
+// The list must be constructed with New beforehand, so that it knows how to order its values.
+//
+//	l := list.New[int](util.Less[int], util.Equals[int])
+//	err := json.Unmarshal(data, l)
+func (l *List[T]) UnmarshalJSON(data []byte) error {
+	var values []T
+
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	return l.load(values)
+}
+
+// MarshalBinary encodes the list values in the list's order using gob.
+func (l *List[T]) MarshalBinary() ([]byte, error) {
+	return encode.Gob(l.ToSlice())
+}
+
+// UnmarshalBinary replaces the contents of the list with the values encoded by MarshalBinary.
+// Same as with UnmarshalJSON, the list must be constructed with New beforehand.
+func (l *List[T]) UnmarshalBinary(data []byte) error {
+	var values []T
+
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&values); err != nil {
+		return err
+	}
+
+	return l.load(values)
+}
+
+// GobEncode implements gob.GobEncoder.
+func (l *List[T]) GobEncode() ([]byte, error) {
+	return l.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder.
+func (l *List[T]) GobDecode(data []byte) error {
+	return l.UnmarshalBinary(data)
+}
+
+// load pushes all of the decoded values into a new list, which replaces this one only if all of them
+// were pushed, so that a rejected duplicate leaves the list as it was.
+func (l *List[T]) load(values []T) error {
+	if l.isLessFunc == nil || l.isEqualFunc == nil {
+		return ErrMissingFuncs
+	}
+
+	loaded := l.empty()
+
+	for _, val := range values {
+		if err := loaded.Push(val); err != nil {
+			return err
+		}
+	}
+
+	*l = *loaded
+
+	return nil
+}
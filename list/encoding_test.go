@@ -0,0 +1,57 @@
+package list_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/igorroncevic/go-utils/list"
+)
+
+func TestListJSON(t *testing.T) {
+	linkedList := list.New[int](lessFn, equalFn)
+	assert.NoError(t, linkedList.Push(5))
+	assert.NoError(t, linkedList.Push(1))
+	assert.NoError(t, linkedList.Push(3))
+
+	data, err := json.Marshal(linkedList)
+	assert.NoError(t, err)
+	assert.Equal(t, `[1,3,5]`, string(data))
+
+	decoded := list.New[int](lessFn, equalFn)
+	assert.NoError(t, json.Unmarshal(data, decoded))
+	assert.EqualValues(t, []int{1, 3, 5}, decoded.ToSlice())
+
+	// Decoding needs the less and equals functions
+	var empty list.List[int]
+	assert.Equal(t, list.ErrMissingFuncs, json.Unmarshal(data, &empty))
+
+	// A rejected duplicate leaves the list as it was
+	rejecting := list.NewWithPolicy[int](lessFn, equalFn, list.RejectDuplicates)
+	assert.NoError(t, rejecting.Push(7))
+	assert.Equal(t, list.ErrDuplicate, json.Unmarshal([]byte(`[1,2,2]`), rejecting))
+	assert.EqualValues(t, []int{7}, rejecting.ToSlice())
+	assert.Equal(t, 1, rejecting.Size(), "unexpected list size after failed decode")
+
+	// Reversed lists are encoded in their order and decoded into it
+	data, err = json.Marshal(linkedList.Reverse())
+	assert.NoError(t, err)
+	assert.Equal(t, `[5,3,1]`, string(data))
+	assert.NoError(t, json.Unmarshal([]byte(`[1,5,3]`), linkedList))
+	assert.EqualValues(t, []int{5, 3, 1}, linkedList.ToSlice())
+}
+
+func TestListGob(t *testing.T) {
+	linkedList := list.New[int](lessFn, equalFn)
+	assert.NoError(t, linkedList.Push(2))
+	assert.NoError(t, linkedList.Push(4))
+
+	var buf bytes.Buffer
+	assert.NoError(t, gob.NewEncoder(&buf).Encode(linkedList))
+
+	decoded := list.New[int](lessFn, equalFn)
+	assert.NoError(t, gob.NewDecoder(&buf).Decode(decoded))
+	assert.EqualValues(t, []int{2, 4}, decoded.ToSlice())
+}
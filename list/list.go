@@ -1,10 +1,37 @@
 package list
 
-import "github.com/igorroncevic/go-utils/util"
-
-// List is an implementation of single linked-list. No duplicates allowed.
+import (
+	"fmt"
+
+	"github.com/igorroncevic/go-utils/util"
+)
+
+var (
+	ErrDuplicate = fmt.Errorf("value already exists in the list")
+)
+
+// DuplicatePolicy determines what happens when a value equal to an existing one is pushed.
+// Two values are equal if neither is less than the other and the equals function reports them as equal.
+type DuplicatePolicy int
+
+const (
+	// AllowDuplicates keeps all of the equal values, in the order they were pushed in.
+	AllowDuplicates DuplicatePolicy = iota
+	// RejectDuplicates makes Push return ErrDuplicate.
+	RejectDuplicates
+	// ReplaceDuplicates overwrites the existing value with the pushed one.
+	ReplaceDuplicates
+)
+
+// List is an implementation of a sorted doubly linked-list.
+// Duplicates are handled according to its DuplicatePolicy, and are allowed by default.
 type List[T any] struct {
-	Head *Node[T]
+	chain[T]
+
+	policy DuplicatePolicy
+	// descending is set after an odd number of reversals. isLessFunc always stays the one the list
+	// was constructed with, so comparisons in the list's order go through isBefore.
+	descending bool
 
 	isLessFunc  util.LessFn[T]
 	isEqualFunc util.EqualsFn[T]
@@ -13,6 +40,9 @@ type List[T any] struct {
 type Node[T any] struct {
 	Value      T
 	Next, Prev *Node[T]
+
+	// owner is the owner of the list the node belongs to, or nil once it is removed.
+	owner *owner
 }
 
 func New[T any](isLessFunc util.LessFn[T], isEqualFunc util.EqualsFn[T]) *List[T] {
@@ -22,183 +52,105 @@ func New[T any](isLessFunc util.LessFn[T], isEqualFunc util.EqualsFn[T]) *List[T
 	}
 }
 
-// Push adds a value to the list in an ascending order.
-func (l *List[T]) Push(val T) {
-	// If there's no head, create it
-	if l.Head == nil {
-		l.Head = &Node[T]{
-			Value: val,
-		}
-
-		return
-	}
-
-	// head >= val - If element is smaller than head, it should be the new head
-	if !l.isLessFunc(l.Head.Value, val) {
-		oldHead := l.Head
-		l.Head = &Node[T]{
-			Value: val,
-			Next:  oldHead,
-		}
+// NewWithPolicy constructs a list that handles duplicates according to the given policy.
+func NewWithPolicy[T any](isLessFunc util.LessFn[T], isEqualFunc util.EqualsFn[T], policy DuplicatePolicy) *List[T] {
+	l := New(isLessFunc, isEqualFunc)
+	l.policy = policy
 
-		l.Head.Next.Prev = l.Head
-
-		if oldHead.Next != nil {
-			l.Head.Next.Next = oldHead.Next
-		}
+	return l
+}
 
-		return
-	}
+// Push adds a value to the list in an ascending order. Equal values are kept in the order
+// they were pushed in, unless the list's policy says otherwise.
+// Values that are greater than or equal to the tail are appended in O(1).
+func (l *List[T]) Push(val T) error {
+	prev := l.insertionPoint(val)
 
-	l.Head.Each(func(curr *Node[T]) bool {
-		// curr < val - we skip over this node
-		// Sorted list requires us to find first node where `node.Value > val`
-		if l.isLessFunc(curr.Value, val) {
-			// Unless, we are at the last node and value is still not smaller -> 'val' should be in the last node
-			if curr.Next == nil {
-				newNode := Node[T]{
-					Value: val,
-					Next:  curr.Next,
-					Prev:  curr,
-				}
-				curr.Next = &newNode
-				return true
+	if l.policy != AllowDuplicates {
+		if existing := l.findEqualBefore(prev, val); existing != nil {
+			if l.policy == RejectDuplicates {
+				return ErrDuplicate
 			}
 
-			return false
-		}
-
-		// Important: Go back one step, because we've found a first element that is larger than val
-		// therefore, previous element should still be smaller and we should put 'val' in between them.
-		if curr.Prev != nil {
-			curr = curr.Prev
-		}
+			existing.Value = val
 
-		// 1. curr <-> next
-		// 2. insert newNode
-		// 	- 2.a. curr.Next ----> newNode,
-		// 	- 2.b. newNode.Next -> next
-		// 	- 2.c. curr <-------- newNode.Prev,
-		// 	- 2.d. newNode <----- next.Prev
-		// result: curr <-> newNode <-> next
-
-		// 2.b, 2.c.
-		newNode := Node[T]{
-			Value: val,
-			Next:  curr.Next,
-			Prev:  curr,
+			return nil
 		}
+	}
 
-		// 2.d
-		if curr.Next != nil {
-			curr.Next.Prev = &newNode
-		}
-
-		// 2.a.
-		curr.Next = &newNode
-
-		return true
+	l.insertAfter(prev, &Node[T]{
+		Value: val,
 	})
+
+	return nil
 }
 
-// Reverse returns a new reversed list.
+// Reverse reverses the list in place and returns it. From then on, the list is kept
+// in a descending order, so that following pushes still end up in the right spot.
 func (l *List[T]) Reverse() *List[T] {
-	listCopy := l.Copy()
-
-	var (
-		tempPrev, tempNext *Node[T]
-		curr               = listCopy.Head
-	)
+	curr := l.Head
 
 	for curr != nil {
-		// Temporary store old values
-		tempNext = curr.Next
-		tempPrev = curr.Prev
-
-		// Reverse current with temporary old values
-		curr.Next = tempPrev
-		curr.Prev = tempNext
-
-		// Move forward
-		tempPrev = curr
-		curr = tempNext
+		// Swap the pointers, then move on to what used to be the next node
+		curr.Next, curr.Prev = curr.Prev, curr.Next
+		curr = curr.Prev
 	}
 
-	listCopy.Head = tempPrev
+	l.Head, l.Tail = l.Tail, l.Head
+	l.descending = !l.descending
 
-	return listCopy
+	return l
 }
 
-// Remove removes the node with value 'val' from the list.
+// Remove removes the first node with value 'val' from the list.
 func (l *List[T]) Remove(val T) {
-	// If no head, there's nothing to remove
-	if l.Head == nil {
-		return
+	if node := l.Get(val); node != nil {
+		l.unlink(node)
 	}
+}
 
-	// If there's head, check if we're deleting its value
-	if l.Head != nil {
-		if l.isEqualFunc(l.Head.Value, val) {
-			if l.Head.Next == nil {
-				l.Clear()
-				return
-			}
+// RemoveAll removes all of the nodes with value 'val' from the list and returns how many were removed.
+func (l *List[T]) RemoveAll(val T) int {
+	var removed int
 
-			next := l.Head.Next
-			l.Head = next
-			l.Head.Prev = nil
+	curr := l.Head
+	for curr != nil {
+		next := curr.Next
 
-			return
+		if l.isEqualFunc(curr.Value, val) {
+			l.unlink(curr)
+			removed++
 		}
-	}
 
-	l.Head.Each(func(curr *Node[T]) bool {
-		// If this is not the values we're looking for, move on
-		if !l.isEqualFunc(curr.Value, val) {
-			return false
-		}
+		curr = next
+	}
 
-		// 1. prev <-> curr <-> next
-		// 2. remove newNode
-		// 	- 2.a. prev -> next ===== curr.Prev.Next -> curr.Next
-		// 	- 2.b. prev <- next ===== curr.Next.Prev -> curr.Prev
+	return removed
+}
 
-		// 2.a.
-		if curr.Prev != nil {
-			curr.Prev.Next = curr.Next
-		}
+// Count returns the number of nodes with value 'val' in the list.
+func (l *List[T]) Count(val T) int {
+	var count int
 
-		// 2.b.
-		if curr.Next != nil {
-			curr.Next.Prev = curr.Prev
+	l.Head.Each(func(curr *Node[T]) bool {
+		if l.isEqualFunc(curr.Value, val) {
+			count++
 		}
 
-		return true
+		return false
 	})
+
+	return count
 }
 
 // Contains returns whether the value is contained in the list.
 func (l *List[T]) Contains(val T) bool {
-	var found bool
-
-	l.Head.Each(func(curr *Node[T]) bool {
-		if !l.isEqualFunc(curr.Value, val) {
-			return false
-		}
-
-		found = true
-		return found
-	})
-
-	return found
+	return l.Get(val) != nil
 }
 
 // Get returns a node with the specified value.
 func (l *List[T]) Get(val T) *Node[T] {
-	var (
-		found bool
-		node  *Node[T]
-	)
+	var node *Node[T]
 
 	l.Head.Each(func(curr *Node[T]) bool {
 		if !l.isEqualFunc(curr.Value, val) {
@@ -206,57 +158,60 @@ func (l *List[T]) Get(val T) *Node[T] {
 		}
 
 		node = curr
-		found = true
-		return found
+
+		return true
 	})
 
 	return node
 }
 
-// Size returns the number of elements in the list.
-func (l *List[T]) Size() int {
-	var size int
+// Copy creates a new list with same values as the original.
+func (l *List[T]) Copy() *List[T] {
+	copy := l.empty()
 
-	l.Head.Each(func(n *Node[T]) bool {
-		size++
-		return false
-	})
+	// Values are already sorted, so each one of them simply goes to the end
+	copy.appendFrom(l.Head)
 
-	return size
+	return copy
 }
 
-// Clear clears the list completely.
-func (l *List[T]) Clear() {
-	l.Head = nil
+// isBefore returns whether 'a' goes before 'b' in the list's current order, which is descending after a reversal.
+func (l *List[T]) isBefore(a, b T) bool {
+	if l.descending {
+		return l.isLessFunc(b, a)
+	}
+
+	return l.isLessFunc(a, b)
 }
 
-// Copy creates a new list with same values as the original.
-func (l *List[T]) Copy() *List[T] {
-	copy := &List[T]{
-		isLessFunc:  l.isLessFunc,
-		isEqualFunc: l.isEqualFunc,
+// insertionPoint returns the last node where `node.Value <= val`, after which 'val' should be inserted,
+// or nil if 'val' should become the new head.
+func (l *List[T]) insertionPoint(val T) *Node[T] {
+	// tail <= val - 'val' should be the new tail, which also covers the empty list
+	if l.Tail == nil || !l.isBefore(val, l.Tail.Value) {
+		return l.Tail
 	}
 
-	// Recreate the list from a slice to avoid pointer issues
-	sliced := l.ToSlice()
-
-	for _, elem := range sliced {
-		copy.Push(elem)
+	// Sorted list requires us to find first node where `node.Value > val`,
+	// which exists, since 'val' is smaller than the tail.
+	curr := l.Head
+	for !l.isBefore(val, curr.Value) {
+		curr = curr.Next
 	}
 
-	return copy
+	return curr.Prev
 }
 
-// ToSlice returns a slice representation of the list.
-func (l *List[T]) ToSlice() []T {
-	sliced := []T{}
-
-	l.Head.Each(func(n *Node[T]) bool {
-		sliced = append(sliced, n.Value)
-		return false
-	})
+// findEqualBefore looks for a node equal to 'val', going backwards from 'node'
+// for as long as the nodes are not less than 'val'.
+func (l *List[T]) findEqualBefore(node *Node[T], val T) *Node[T] {
+	for ; node != nil && !l.isBefore(node.Value, val); node = node.Prev {
+		if l.isEqualFunc(node.Value, val) {
+			return node
+		}
+	}
 
-	return sliced
+	return nil
 }
 
 // EachNode calls 'fn' on every node from this node onward in the list.
@@ -270,3 +225,16 @@ func (n *Node[T]) Each(fn func(n *Node[T]) bool) {
 		node = node.Next
 	}
 }
+
+// EachReverse calls 'fn' on every node from this node backwards in the list,
+// which starting from the list's tail means in descending order.
+func (n *Node[T]) EachReverse(fn func(n *Node[T]) bool) {
+	node := n
+	for node != nil {
+		if shouldStop := fn(node); shouldStop {
+			return
+		}
+
+		node = node.Prev
+	}
+}
@@ -19,9 +19,9 @@ func TestList(t *testing.T) {
 	linkedList := list.New[int](lessFn, equalFn)
 
 	// Add elements
-	linkedList.Push(1)
-	linkedList.Push(2)
-	linkedList.Push(5)
+	assert.NoError(t, linkedList.Push(1))
+	assert.NoError(t, linkedList.Push(2))
+	assert.NoError(t, linkedList.Push(5))
 
 	assert.Equal(t, 3, linkedList.Size(), "unexpected list size")
 	assert.EqualValues(t, []int{1, 2, 5}, linkedList.ToSlice(), "unexpected slice values")
@@ -34,10 +34,10 @@ func TestList(t *testing.T) {
 	assert.EqualValues(t, []int{2, 5}, linkedList.ToSlice(), "unexpected slice values after remove")
 
 	// Add more elements
-	linkedList.Push(10)
-	linkedList.Push(3)
-	linkedList.Push(6)
-	linkedList.Push(4)
+	assert.NoError(t, linkedList.Push(10))
+	assert.NoError(t, linkedList.Push(3))
+	assert.NoError(t, linkedList.Push(6))
+	assert.NoError(t, linkedList.Push(4))
 
 	assert.Equal(t, 6, linkedList.Size(), "unexpected list size after remove and another add")
 	assert.EqualValues(t, []int{2, 3, 4, 5, 6, 10}, linkedList.ToSlice(), "unexpected slice values after remove and another add")
@@ -61,3 +61,126 @@ func TestList(t *testing.T) {
 
 	assert.Equal(t, 0, reversedList.Size(), "unexpected list size after clear")
 }
+
+func TestListTail(t *testing.T) {
+	linkedList := list.New[int](lessFn, equalFn)
+
+	// Values pushed in order are appended at the tail
+	for i := 1; i <= 5; i++ {
+		assert.NoError(t, linkedList.Push(i))
+		assert.Equal(t, i, linkedList.Tail.Value, "unexpected tail value")
+		assert.Equal(t, i, linkedList.Size(), "unexpected list size")
+	}
+
+	assert.NoError(t, linkedList.Push(0))
+	assert.Equal(t, 0, linkedList.Head.Value, "unexpected head value")
+	assert.Equal(t, 5, linkedList.Tail.Value, "unexpected tail value")
+
+	// Removing the tail moves it back
+	linkedList.Remove(5)
+	assert.Equal(t, 4, linkedList.Tail.Value, "unexpected tail value after remove")
+	assert.Nil(t, linkedList.Tail.Next, "tail has a next node")
+
+	// Reverse iteration starts from the tail
+	reversed := []int{}
+
+	linkedList.Tail.EachReverse(func(n *list.Node[int]) bool {
+		reversed = append(reversed, n.Value)
+		return false
+	})
+
+	assert.EqualValues(t, []int{4, 3, 2, 1, 0}, reversed, "unexpected reverse iteration")
+
+	// Copies are independent
+	cpy := linkedList.Copy()
+	assert.NoError(t, cpy.Push(10))
+	assert.Equal(t, 5, linkedList.Size(), "original list was affected by copy")
+	assert.Equal(t, 10, cpy.Tail.Value, "unexpected copy tail value")
+}
+
+func TestListReverseInPlace(t *testing.T) {
+	linkedList := list.New[int](lessFn, equalFn)
+	assert.NoError(t, linkedList.Push(1))
+	assert.NoError(t, linkedList.Push(3))
+	assert.NoError(t, linkedList.Push(5))
+
+	reversed := linkedList.Reverse()
+	assert.True(t, reversed == linkedList, "list was not reversed in place")
+	assert.Equal(t, 5, linkedList.Head.Value, "unexpected head after reverse")
+	assert.Equal(t, 1, linkedList.Tail.Value, "unexpected tail after reverse")
+
+	// Reversed list keeps the descending order on push
+	assert.NoError(t, linkedList.Push(4))
+	assert.NoError(t, linkedList.Push(0))
+	assert.NoError(t, linkedList.Push(6))
+
+	assert.EqualValues(t, []int{6, 5, 4, 3, 1, 0}, linkedList.ToSlice(), "unexpected values after reverse and push")
+	assert.Equal(t, 6, linkedList.Size(), "unexpected list size")
+}
+
+func TestListRepeatedReverse(t *testing.T) {
+	linkedList := list.New[int](lessFn, equalFn)
+	assert.NoError(t, linkedList.Push(2))
+
+	for i := 0; i < 1001; i++ {
+		linkedList.Reverse()
+	}
+
+	// An odd number of reversals leaves the list descending
+	assert.NoError(t, linkedList.Push(1))
+	assert.NoError(t, linkedList.Push(3))
+	assert.EqualValues(t, []int{3, 2, 1}, linkedList.ToSlice(), "unexpected values after odd reversals")
+
+	minVal, _ := linkedList.Min()
+	maxVal, _ := linkedList.Max()
+	assert.Equal(t, 1, minVal, "unexpected min after odd reversals")
+	assert.Equal(t, 3, maxVal, "unexpected max after odd reversals")
+
+	linkedList.Reverse()
+	assert.NoError(t, linkedList.Push(0))
+	assert.EqualValues(t, []int{0, 1, 2, 3}, linkedList.ToSlice(), "unexpected values after even reversals")
+}
+
+type scored struct {
+	id    string
+	score int
+}
+
+func TestListDuplicatePolicies(t *testing.T) {
+	less := func(a, b scored) bool { return a.score < b.score }
+	equal := func(a, b scored) bool { return a.score == b.score }
+
+	// Allowed duplicates keep the order they were pushed in
+	allowed := list.New[scored](less, equal)
+	assert.NoError(t, allowed.Push(scored{"a", 1}))
+	assert.NoError(t, allowed.Push(scored{"b", 2}))
+	assert.NoError(t, allowed.Push(scored{"c", 1}))
+	assert.NoError(t, allowed.Push(scored{"d", 1}))
+	assert.NoError(t, allowed.Push(scored{"e", 0}))
+
+	assert.EqualValues(t, []scored{{"e", 0}, {"a", 1}, {"c", 1}, {"d", 1}, {"b", 2}}, allowed.ToSlice(), "unexpected order of duplicates")
+	assert.Equal(t, 3, allowed.Count(scored{score: 1}), "unexpected count of duplicates")
+
+	assert.Equal(t, 3, allowed.RemoveAll(scored{score: 1}), "unexpected number of removed duplicates")
+	assert.Equal(t, 0, allowed.Count(scored{score: 1}), "duplicates were not removed")
+	assert.EqualValues(t, []scored{{"e", 0}, {"b", 2}}, allowed.ToSlice(), "unexpected values after remove all")
+
+	// Rejected duplicates return an error
+	rejected := list.NewWithPolicy[scored](less, equal, list.RejectDuplicates)
+	assert.NoError(t, rejected.Push(scored{"a", 1}))
+	assert.NoError(t, rejected.Push(scored{"b", 2}))
+	assert.Equal(t, list.ErrDuplicate, rejected.Push(scored{"c", 1}))
+	assert.Equal(t, list.ErrDuplicate, rejected.Push(scored{"d", 2}))
+	assert.EqualValues(t, []scored{{"a", 1}, {"b", 2}}, rejected.ToSlice(), "rejected duplicate was added")
+
+	// Replaced duplicates overwrite the existing value
+	replaced := list.NewWithPolicy[scored](less, equal, list.ReplaceDuplicates)
+	assert.NoError(t, replaced.Push(scored{"a", 1}))
+	assert.NoError(t, replaced.Push(scored{"b", 2}))
+	assert.NoError(t, replaced.Push(scored{"c", 1}))
+	assert.EqualValues(t, []scored{{"c", 1}, {"b", 2}}, replaced.ToSlice(), "duplicate was not replaced")
+	assert.Equal(t, 2, replaced.Size(), "unexpected list size after replace")
+
+	// Copies keep the policy
+	assert.Equal(t, list.ErrDuplicate, rejected.Copy().Push(scored{"e", 1}))
+}
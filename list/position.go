@@ -0,0 +1,163 @@
+package list
+
+import "fmt"
+
+var (
+	ErrOutOfRange = fmt.Errorf("index out of range")
+)
+
+// View is a read-only window over consecutive nodes of a list. It does not copy any values,
+// so it is only valid until the underlying list is modified.
+type View[T any] struct {
+	first  *Node[T]
+	length int
+}
+
+// At returns the value at the 0-based index 'i', walking from whichever end of the list is nearer.
+func (l *chain[T]) At(i int) (T, bool) {
+	if node := l.nodeAt(i); node != nil {
+		return node.Value, true
+	}
+
+	var empty T
+
+	return empty, false
+}
+
+// First returns the value at the head of the list, or false if the list is empty.
+func (l *chain[T]) First() (T, bool) {
+	return l.At(0)
+}
+
+// Last returns the value at the tail of the list, or false if the list is empty.
+func (l *chain[T]) Last() (T, bool) {
+	return l.At(l.length - 1)
+}
+
+// Slice returns a view of the values with indexes in the [from:to) range.
+func (l *chain[T]) Slice(from, to int) (*View[T], error) {
+	if from < 0 || to > l.length || from > to {
+		return nil, ErrOutOfRange
+	}
+
+	return &View[T]{
+		first:  l.nodeAt(from),
+		length: to - from,
+	}, nil
+}
+
+// IndexOf returns the 0-based index of the first node with value 'val', or -1 if there is none.
+func (l *List[T]) IndexOf(val T) int {
+	var (
+		index = -1
+		i     int
+	)
+
+	l.Head.Each(func(curr *Node[T]) bool {
+		if l.isEqualFunc(curr.Value, val) {
+			index = i
+			return true
+		}
+
+		i++
+
+		return false
+	})
+
+	return index
+}
+
+// Min returns the smallest value in the list according to the less func it was constructed with,
+// which is at its head, or at its tail once the list is reversed.
+func (l *List[T]) Min() (T, bool) {
+	if l.descending {
+		return l.Last()
+	}
+
+	return l.First()
+}
+
+// Max returns the largest value in the list according to the less func it was constructed with,
+// which is at its tail, or at its head once the list is reversed.
+func (l *List[T]) Max() (T, bool) {
+	if l.descending {
+		return l.First()
+	}
+
+	return l.Last()
+}
+
+// nodeAt returns the node at the 0-based index 'i', or nil if it is out of range.
+func (l *chain[T]) nodeAt(i int) *Node[T] {
+	if i < 0 || i >= l.length {
+		return nil
+	}
+
+	if i < l.length/2 {
+		node := l.Head
+		for ; i > 0; i-- {
+			node = node.Next
+		}
+
+		return node
+	}
+
+	node := l.Tail
+	for j := l.length - 1; j > i; j-- {
+		node = node.Prev
+	}
+
+	return node
+}
+
+// Size returns the number of values in the view.
+func (v *View[T]) Size() int {
+	return v.length
+}
+
+// At returns the value at the 0-based index 'i' of the view.
+func (v *View[T]) At(i int) (T, bool) {
+	var (
+		found bool
+		value T
+	)
+
+	if i >= 0 && i < v.length {
+		v.Each(func(val T) bool {
+			if i == 0 {
+				value, found = val, true
+				return true
+			}
+
+			i--
+
+			return false
+		})
+	}
+
+	return value, found
+}
+
+// Each calls 'fn' on every value in the view. Iteration stops as soon as 'fn' returns true.
+func (v *View[T]) Each(fn func(val T) bool) {
+	node := v.first
+	for i := 0; i < v.length; i++ {
+		if shouldStop := fn(node.Value); shouldStop {
+			return
+		}
+
+		node = node.Next
+	}
+}
+
+// ToSlice returns a slice representation of the view.
+func (v *View[T]) ToSlice() []T {
+	sliced := make([]T, 0, v.length)
+
+	v.Each(func(val T) bool {
+		sliced = append(sliced, val)
+		return false
+	})
+
+	return sliced
+}
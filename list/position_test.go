@@ -0,0 +1,91 @@
+package list_test
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/igorroncevic/go-utils/list"
+)
+
+func TestListPositions(t *testing.T) {
+	l := fromSlice(t, []int{10, 20, 30, 40, 50})
+
+	for i, expected := range []int{10, 20, 30, 40, 50} {
+		val, ok := l.At(i)
+		assert.True(t, ok, "index %d not found", i)
+		assert.Equal(t, expected, val)
+		assert.Equal(t, i, l.IndexOf(expected))
+	}
+
+	_, ok := l.At(5)
+	assert.False(t, ok)
+	assert.Equal(t, -1, l.IndexOf(25))
+
+	first, _ := l.First()
+	assert.Equal(t, 10, first)
+
+	last, _ := l.Last()
+	assert.Equal(t, 50, last)
+
+	min, _ := l.Min()
+	assert.Equal(t, 10, min)
+
+	max, _ := l.Max()
+	assert.Equal(t, 50, max)
+
+	empty := list.New[int](lessFn, equalFn)
+	_, ok = empty.First()
+	assert.False(t, ok)
+}
+
+func TestListMinMaxAfterReverse(t *testing.T) {
+	l := fromSlice(t, []int{1, 2, 3}).Reverse()
+
+	// Min and Max follow the original less func, not the order of the reversed list
+	min, _ := l.Min()
+	assert.Equal(t, 1, min)
+
+	max, _ := l.Max()
+	assert.Equal(t, 3, max)
+
+	// Reversing back restores the original ends
+	l.Reverse()
+
+	min, _ = l.Min()
+	assert.Equal(t, 1, min)
+
+	max, _ = l.Max()
+	assert.Equal(t, 3, max)
+
+	// Copies of a reversed list are reversed as well
+	copy := l.Reverse().Copy()
+
+	min, _ = copy.Min()
+	assert.Equal(t, 1, min)
+}
+
+func TestListSlice(t *testing.T) {
+	l := fromSlice(t, []int{10, 20, 30, 40, 50})
+
+	view, err := l.Slice(1, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, view.Size())
+	assert.EqualValues(t, []int{20, 30, 40}, view.ToSlice())
+
+	val, ok := view.At(2)
+	assert.True(t, ok)
+	assert.Equal(t, 40, val)
+
+	_, ok = view.At(3)
+	assert.False(t, ok)
+
+	view, err = l.Slice(5, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, view.Size())
+
+	_, err = l.Slice(3, 6)
+	assert.Equal(t, list.ErrOutOfRange, err)
+
+	_, err = l.Slice(3, 2)
+	assert.Equal(t, list.ErrOutOfRange, err)
+}
@@ -0,0 +1,180 @@
+package list
+
+import (
+	"fmt"
+
+	"github.com/igorroncevic/go-utils/util"
+)
+
+var (
+	ErrNotSorted = fmt.Errorf("values are not sorted in an ascending order")
+)
+
+// FromSortedSlice constructs a list from values that are already sorted in an ascending order,
+// appending each one of them in O(1). If the values are not sorted, ErrNotSorted is returned.
+func FromSortedSlice[T any](isLessFunc util.LessFn[T], isEqualFunc util.EqualsFn[T], values []T) (*List[T], error) {
+	l := New(isLessFunc, isEqualFunc)
+
+	for i, val := range values {
+		if i > 0 && isLessFunc(val, values[i-1]) {
+			return nil, ErrNotSorted
+		}
+
+		l.append(val)
+	}
+
+	return l, nil
+}
+
+// Merge returns a new list, sorted in this list's order, with the values of both lists in O(n+m).
+// Equal values are handled according to this list's policy, the same as if the values of 'other' were
+// pushed after the ones of this list: all of them are kept, only the first one is kept (RejectDuplicates),
+// or the last one replaces the earlier ones (ReplaceDuplicates).
+func (l *List[T]) Merge(other *List[T]) *List[T] {
+	var (
+		merged = l.empty()
+		a, b   = l.Head, l.firstOf(other)
+	)
+
+	for a != nil || b != nil {
+		// a <= b - equal values from this list go first, to keep the order stable
+		if b == nil || a != nil && !l.isBefore(b.Value, a.Value) {
+			merged.appendWithPolicy(a.Value)
+			a = a.Next
+		} else {
+			merged.appendWithPolicy(b.Value)
+			b = l.nextOf(other, b)
+		}
+	}
+
+	return merged
+}
+
+// Intersect returns a new list, sorted in this list's order, with the values of this list that are
+// also in 'other', in O(n+m). Each value in 'other' is matched at most once.
+func (l *List[T]) Intersect(other *List[T]) *List[T] {
+	var (
+		intersection = l.empty()
+		a, b         = l.Head, l.firstOf(other)
+	)
+
+	for a != nil && b != nil {
+		switch {
+		case l.isBefore(a.Value, b.Value):
+			a = a.Next
+		case l.isBefore(b.Value, a.Value):
+			b = l.nextOf(other, b)
+		case l.isEqualFunc(a.Value, b.Value):
+			intersection.append(a.Value)
+			a, b = a.Next, l.nextOf(other, b)
+		default:
+			a = a.Next
+		}
+	}
+
+	return intersection
+}
+
+// Difference returns a new list, sorted in this list's order, with the values of this list that are
+// not in 'other', in O(n+m). Each value in 'other' cancels out at most one value of this list.
+func (l *List[T]) Difference(other *List[T]) *List[T] {
+	var (
+		difference = l.empty()
+		a, b       = l.Head, l.firstOf(other)
+	)
+
+	for a != nil && b != nil {
+		switch {
+		case l.isBefore(a.Value, b.Value):
+			difference.append(a.Value)
+			a = a.Next
+		case l.isBefore(b.Value, a.Value):
+			b = l.nextOf(other, b)
+		case l.isEqualFunc(a.Value, b.Value):
+			a, b = a.Next, l.nextOf(other, b)
+		default:
+			difference.append(a.Value)
+			a = a.Next
+		}
+	}
+
+	difference.appendFrom(a)
+
+	return difference
+}
+
+// SplitAt returns two new lists, the first one with values less than 'val' according to the less func
+// the list was constructed with, and the second one with the rest of them. Both keep the order of this list,
+// so after a reversal the first list holds the tail of this one.
+func (l *List[T]) SplitAt(val T) (*List[T], *List[T]) {
+	lower, upper := l.empty(), l.empty()
+
+	l.Head.Each(func(curr *Node[T]) bool {
+		if l.isLessFunc(curr.Value, val) {
+			lower.append(curr.Value)
+		} else {
+			upper.append(curr.Value)
+		}
+
+		return false
+	})
+
+	return lower, upper
+}
+
+// firstOf returns the node of 'other' that goes first in this list's order, which is its tail
+// if only one of the lists was reversed.
+func (l *List[T]) firstOf(other *List[T]) *Node[T] {
+	if other.descending != l.descending {
+		return other.Tail
+	}
+
+	return other.Head
+}
+
+// nextOf returns the node of 'other' that follows 'node' in this list's order.
+func (l *List[T]) nextOf(other *List[T], node *Node[T]) *Node[T] {
+	if other.descending != l.descending {
+		return node.Prev
+	}
+
+	return node.Next
+}
+
+// empty returns a new, empty list with the same functions and policy as this one.
+func (l *List[T]) empty() *List[T] {
+	return &List[T]{
+		policy:      l.policy,
+		descending:  l.descending,
+		isLessFunc:  l.isLessFunc,
+		isEqualFunc: l.isEqualFunc,
+	}
+}
+
+// append adds the value to the end of the list, which the caller must make sure keeps the list sorted.
+func (l *List[T]) append(val T) {
+	l.insertAfter(l.Tail, &Node[T]{Value: val})
+}
+
+// appendWithPolicy appends the value like append, but handles duplicates according to the list's policy.
+func (l *List[T]) appendWithPolicy(val T) {
+	if l.policy != AllowDuplicates {
+		if existing := l.findEqualBefore(l.Tail, val); existing != nil {
+			if l.policy == ReplaceDuplicates {
+				existing.Value = val
+			}
+
+			return
+		}
+	}
+
+	l.append(val)
+}
+
+// appendFrom appends the values of 'node' and all of the nodes after it.
+func (l *List[T]) appendFrom(node *Node[T]) {
+	node.Each(func(n *Node[T]) bool {
+		l.append(n.Value)
+		return false
+	})
+}
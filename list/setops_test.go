@@ -0,0 +1,122 @@
+package list_test
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/igorroncevic/go-utils/list"
+)
+
+func fromSlice(t *testing.T, values []int) *list.List[int] {
+	l, err := list.FromSortedSlice[int](lessFn, equalFn, values)
+	assert.NoError(t, err)
+
+	return l
+}
+
+func TestListFromSortedSlice(t *testing.T) {
+	l := fromSlice(t, []int{1, 2, 2, 5})
+	assert.Equal(t, 4, l.Size(), "unexpected list size")
+	assert.EqualValues(t, []int{1, 2, 2, 5}, l.ToSlice())
+	assert.Equal(t, 5, l.Tail.Value, "unexpected tail value")
+
+	_, err := list.FromSortedSlice[int](lessFn, equalFn, []int{1, 3, 2})
+	assert.Equal(t, list.ErrNotSorted, err)
+}
+
+func TestListMerge(t *testing.T) {
+	a := fromSlice(t, []int{1, 3, 5, 7})
+	b := fromSlice(t, []int{2, 3, 6, 8, 9})
+
+	merged := a.Merge(b)
+	assert.EqualValues(t, []int{1, 2, 3, 3, 5, 6, 7, 8, 9}, merged.ToSlice())
+	assert.Equal(t, 9, merged.Size(), "unexpected merged size")
+
+	// Originals are left untouched
+	assert.EqualValues(t, []int{1, 3, 5, 7}, a.ToSlice())
+
+	// Duplicates are dropped when the policy rejects them
+	unique := list.NewWithPolicy[int](lessFn, equalFn, list.RejectDuplicates)
+	for _, val := range []int{1, 3, 5} {
+		assert.NoError(t, unique.Push(val))
+	}
+
+	assert.EqualValues(t, []int{1, 2, 3, 5, 6, 8, 9}, unique.Merge(b).ToSlice())
+
+	// Duplicates within 'other' are dropped as well
+	rejecting := list.NewWithPolicy[int](lessFn, equalFn, list.RejectDuplicates)
+	assert.NoError(t, rejecting.Push(1))
+
+	merged = rejecting.Merge(fromSlice(t, []int{1, 1, 2, 2}))
+	assert.EqualValues(t, []int{1, 2}, merged.ToSlice())
+	assert.Equal(t, 2, merged.Size(), "unexpected merged size")
+}
+
+func TestListMergeReplace(t *testing.T) {
+	type entry struct {
+		key, tag int
+	}
+
+	var (
+		less  = func(a, b entry) bool { return a.key < b.key }
+		equal = func(a, b entry) bool { return a.key == b.key }
+	)
+
+	replacing := list.NewWithPolicy(less, equal, list.ReplaceDuplicates)
+	assert.NoError(t, replacing.Push(entry{1, 0}))
+	assert.NoError(t, replacing.Push(entry{3, 0}))
+
+	other := list.New(less, equal)
+	for _, e := range []entry{{1, 1}, {1, 2}, {2, 1}, {2, 2}} {
+		assert.NoError(t, other.Push(e))
+	}
+
+	// The last of the equal values wins, including duplicates within 'other'
+	assert.EqualValues(t, []entry{{1, 2}, {2, 2}, {3, 0}}, replacing.Merge(other).ToSlice())
+}
+
+func TestListIntersectAndDifference(t *testing.T) {
+	a := fromSlice(t, []int{1, 2, 2, 3, 5, 8})
+	b := fromSlice(t, []int{2, 3, 4, 8, 10})
+
+	assert.EqualValues(t, []int{2, 3, 8}, a.Intersect(b).ToSlice())
+	assert.EqualValues(t, []int{1, 2, 5}, a.Difference(b).ToSlice())
+	assert.EqualValues(t, []int{4, 10}, b.Difference(a).ToSlice())
+}
+
+func TestListSetOpsMixedDirections(t *testing.T) {
+	// The other list is walked in this list's order, regardless of which one was reversed
+	descending := fromSlice(t, []int{1, 3, 5}).Reverse()
+
+	assert.EqualValues(t, []int{6, 5, 4, 3, 2, 1}, descending.Merge(fromSlice(t, []int{2, 4, 6})).ToSlice())
+	assert.EqualValues(t, []int{5, 3, 1}, descending.Intersect(fromSlice(t, []int{1, 3, 5})).ToSlice())
+	assert.Equal(t, 0, descending.Difference(fromSlice(t, []int{1, 3, 5})).Size(), "unexpected difference size")
+
+	ascending := fromSlice(t, []int{1, 2, 3, 4})
+	other := fromSlice(t, []int{2, 4, 5}).Reverse()
+
+	assert.EqualValues(t, []int{1, 2, 2, 3, 4, 4, 5}, ascending.Merge(other).ToSlice())
+	assert.EqualValues(t, []int{2, 4}, ascending.Intersect(other).ToSlice())
+	assert.EqualValues(t, []int{1, 3}, ascending.Difference(other).ToSlice())
+
+	// Lists reversed the same number of times are walked head to head
+	assert.EqualValues(t, []int{5, 5, 4, 3, 2, 1}, other.Merge(descending).ToSlice())
+}
+
+func TestListSplitAt(t *testing.T) {
+	l := fromSlice(t, []int{1, 3, 5, 7})
+
+	lower, upper := l.SplitAt(5)
+	assert.EqualValues(t, []int{1, 3}, lower.ToSlice())
+	assert.EqualValues(t, []int{5, 7}, upper.ToSlice())
+	assert.Equal(t, 2, upper.Size(), "unexpected upper size")
+
+	lower, upper = l.SplitAt(0)
+	assert.Equal(t, 0, lower.Size(), "unexpected lower size")
+	assert.Equal(t, 4, upper.Size(), "unexpected upper size")
+
+	// After a reversal, lower values are still the ones less than 'val', in the list's descending order
+	lower, upper = fromSlice(t, []int{1, 2, 3}).Reverse().SplitAt(2)
+	assert.EqualValues(t, []int{1}, lower.ToSlice())
+	assert.EqualValues(t, []int{3, 2}, upper.ToSlice())
+}
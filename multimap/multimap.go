@@ -0,0 +1,177 @@
+// Package multimap implements a map where every key can hold multiple values.
+package multimap
+
+import (
+	"github.com/igorroncevic/go-utils/hashmap"
+	"github.com/igorroncevic/go-utils/util"
+)
+
+// MultiMap maps each key to a collection of values, which is either a list (duplicates allowed,
+// insertion order kept) or a set (duplicates ignored), depending on the constructor used.
+type MultiMap[K, V any] struct {
+	values *hashmap.Map[K, []V]
+	length int
+	unique bool
+
+	keyEquals util.EqualsFn[K]
+	keyHash   util.HashFn[K]
+	valEquals util.EqualsFn[V]
+}
+
+// NewList constructs a multimap that keeps a list of values per key, in insertion order.
+func NewList[K, V any](
+	capacity uint64, keyEquals util.EqualsFn[K], keyHash util.HashFn[K], valEquals util.EqualsFn[V],
+) *MultiMap[K, V] {
+	return &MultiMap[K, V]{
+		values:    hashmap.New[K, []V](capacity, keyEquals, keyHash),
+		keyEquals: keyEquals,
+		keyHash:   keyHash,
+		valEquals: valEquals,
+	}
+}
+
+// NewSet constructs a multimap that keeps a set of values per key. Putting a value that
+// the key already holds has no effect.
+func NewSet[K, V any](
+	capacity uint64, keyEquals util.EqualsFn[K], keyHash util.HashFn[K], valEquals util.EqualsFn[V],
+) *MultiMap[K, V] {
+	m := NewList[K, V](capacity, keyEquals, keyHash, valEquals)
+	m.unique = true
+
+	return m
+}
+
+// Put adds the value to the ones already stored for this key.
+func (m *MultiMap[K, V]) Put(key K, val V) {
+	vals, _ := m.values.Get(key)
+
+	if m.unique && m.indexOf(vals, val) != -1 {
+		return
+	}
+
+	m.values.Put(key, append(vals, val))
+	m.length++
+}
+
+// Get returns all of the values stored for this key.
+func (m *MultiMap[K, V]) Get(key K) []V {
+	vals, _ := m.values.Get(key)
+	return clone(vals)
+}
+
+// Contains returns whether there are any values stored for this key.
+func (m *MultiMap[K, V]) Contains(key K) bool {
+	_, ok := m.values.Get(key)
+	return ok
+}
+
+// ContainsValue returns whether the key holds the given value.
+func (m *MultiMap[K, V]) ContainsValue(key K, val V) bool {
+	vals, _ := m.values.Get(key)
+	return m.indexOf(vals, val) != -1
+}
+
+// RemoveValue removes the first occurrence of the value from the ones stored for this key,
+// and returns whether anything was removed.
+func (m *MultiMap[K, V]) RemoveValue(key K, val V) bool {
+	vals, _ := m.values.Get(key)
+
+	idx := m.indexOf(vals, val)
+	if idx == -1 {
+		return false
+	}
+
+	m.length--
+
+	if len(vals) == 1 {
+		m.values.Remove(key)
+		return true
+	}
+
+	remaining := make([]V, 0, len(vals)-1)
+	remaining = append(remaining, vals[:idx]...)
+	remaining = append(remaining, vals[idx+1:]...)
+	m.values.Put(key, remaining)
+
+	return true
+}
+
+// Remove removes the key together with all of its values.
+func (m *MultiMap[K, V]) Remove(key K) {
+	vals, ok := m.values.Get(key)
+	if !ok {
+		return
+	}
+
+	m.length -= len(vals)
+	m.values.Remove(key)
+}
+
+// Clear removes all keys and values from the multimap.
+func (m *MultiMap[K, V]) Clear() {
+	m.values = hashmap.New[K, []V](1, m.keyEquals, m.keyHash)
+	m.length = 0
+}
+
+// Size returns the total number of values in the multimap.
+func (m *MultiMap[K, V]) Size() int {
+	return m.length
+}
+
+// KeyCount returns the number of distinct keys in the multimap.
+func (m *MultiMap[K, V]) KeyCount() int {
+	return m.values.Size()
+}
+
+// Each calls 'fn' on every key-value pair in the multimap. Keys come in no particular order,
+// while the values of a single key are visited in insertion order.
+func (m *MultiMap[K, V]) Each(fn func(key K, val V)) {
+	m.values.Each(func(key K, vals []V) {
+		for _, val := range vals {
+			fn(key, val)
+		}
+	})
+}
+
+// EachKey calls 'fn' on every key in the multimap together with all of its values.
+func (m *MultiMap[K, V]) EachKey(fn func(key K, vals []V)) {
+	m.values.Each(func(key K, vals []V) {
+		fn(key, clone(vals))
+	})
+}
+
+// Copy returns a copy of this multimap.
+func (m *MultiMap[K, V]) Copy() *MultiMap[K, V] {
+	values := hashmap.New[K, []V](uint64(m.values.Size()), m.keyEquals, m.keyHash)
+
+	// Value slices are cloned as well, so that appends to one copy never show up in the other
+	m.values.Each(func(key K, vals []V) {
+		values.Put(key, clone(vals))
+	})
+
+	return &MultiMap[K, V]{
+		values:    values,
+		length:    m.length,
+		unique:    m.unique,
+		keyEquals: m.keyEquals,
+		keyHash:   m.keyHash,
+		valEquals: m.valEquals,
+	}
+}
+
+func (m *MultiMap[K, V]) indexOf(vals []V, val V) int {
+	for i, v := range vals {
+		if m.valEquals(v, val) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func clone[V any](vals []V) []V {
+	result := make([]V, len(vals))
+	copy(result, vals)
+
+	return result
+}
@@ -0,0 +1,67 @@
+package multimap_test
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/igorroncevic/go-utils/multimap"
+	"github.com/igorroncevic/go-utils/util"
+)
+
+func TestMultiMapList(t *testing.T) {
+	m := multimap.NewList[string, int](1, util.Equals[string], util.HashString, util.Equals[int])
+
+	m.Put("foo", 1)
+	m.Put("foo", 2)
+	m.Put("foo", 1)
+	m.Put("bar", 3)
+
+	assert.Equal(t, 4, m.Size(), "unexpected multimap size")
+	assert.Equal(t, 2, m.KeyCount(), "unexpected key count")
+	assert.Equal(t, []int{1, 2, 1}, m.Get("foo"))
+	assert.Equal(t, []int{}, m.Get("baz"))
+	assert.True(t, m.ContainsValue("foo", 2))
+	assert.False(t, m.ContainsValue("bar", 2))
+
+	// Only the first occurrence is removed
+	assert.True(t, m.RemoveValue("foo", 1))
+	assert.Equal(t, []int{2, 1}, m.Get("foo"))
+	assert.False(t, m.RemoveValue("foo", 42))
+
+	// Removing the last value removes the key
+	assert.True(t, m.RemoveValue("bar", 3))
+	assert.False(t, m.Contains("bar"))
+	assert.Equal(t, 2, m.Size(), "unexpected multimap size after remove")
+
+	// Copies do not share value slices
+	cpy := m.Copy()
+	cpy.Put("foo", 5)
+	assert.Equal(t, []int{2, 1}, m.Get("foo"))
+	assert.Equal(t, []int{2, 1, 5}, cpy.Get("foo"))
+
+	m.Remove("foo")
+	assert.Equal(t, 0, m.Size(), "unexpected multimap size after key remove")
+
+	cpy.Clear()
+	assert.Equal(t, 0, cpy.Size(), "unexpected multimap size after clear")
+	assert.Equal(t, 0, cpy.KeyCount(), "unexpected key count after clear")
+}
+
+func TestMultiMapSet(t *testing.T) {
+	m := multimap.NewSet[[]byte, string](1, func(a, b []byte) bool { return string(a) == string(b) }, util.HashBytes, util.Equals[string])
+
+	m.Put([]byte("tag"), "a")
+	m.Put([]byte("tag"), "b")
+	m.Put([]byte("tag"), "a")
+
+	assert.Equal(t, 2, m.Size(), "duplicate value was added")
+	assert.Equal(t, []string{"a", "b"}, m.Get([]byte("tag")))
+
+	count := 0
+
+	m.Each(func(key []byte, val string) {
+		count++
+	})
+
+	assert.Equal(t, 2, count)
+}
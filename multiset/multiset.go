@@ -0,0 +1,155 @@
+// Package multiset implements a multiset, also known as a bag, which is a set that counts how many times it holds each value.
+package multiset
+
+import (
+	"sort"
+
+	"github.com/igorroncevic/go-utils/hashmap"
+	"github.com/igorroncevic/go-utils/util"
+)
+
+// Multiset counts the occurrences of each value, telling values apart with the equals and hash funcs.
+type Multiset[T any] struct {
+	counts *hashmap.Map[T, int]
+	length int
+
+	equals util.EqualsFn[T]
+	hash   util.HashFn[T]
+}
+
+// Entry is a value with the number of its occurrences.
+type Entry[T any] struct {
+	Value T
+	Count int
+}
+
+// New constructs a new, empty multiset with the given capacity.
+func New[T any](capacity uint64, equals util.EqualsFn[T], hash util.HashFn[T]) *Multiset[T] {
+	return &Multiset[T]{
+		counts: hashmap.New[T, int](capacity, equals, hash),
+		equals: equals,
+		hash:   hash,
+	}
+}
+
+// Add adds 'n' occurrences of the value. Non-positive 'n' has no effect.
+func (m *Multiset[T]) Add(val T, n int) {
+	if n <= 0 {
+		return
+	}
+
+	count, _ := m.counts.Get(val)
+	m.counts.Put(val, count+n)
+	m.length += n
+}
+
+// Remove removes up to 'n' occurrences of the value and returns how many were removed.
+func (m *Multiset[T]) Remove(val T, n int) int {
+	count, _ := m.counts.Get(val)
+
+	removed := util.Clamp(n, 0, count)
+	if removed == 0 {
+		return 0
+	}
+
+	if removed == count {
+		m.counts.Remove(val)
+	} else {
+		m.counts.Put(val, count-removed)
+	}
+
+	m.length -= removed
+
+	return removed
+}
+
+// Count returns the number of occurrences of the value.
+func (m *Multiset[T]) Count(val T) int {
+	count, _ := m.counts.Get(val)
+	return count
+}
+
+// Contains returns whether the value occurs at least once.
+func (m *Multiset[T]) Contains(val T) bool {
+	return m.Count(val) > 0
+}
+
+// Distinct returns the number of distinct values.
+func (m *Multiset[T]) Distinct() int {
+	return m.counts.Size()
+}
+
+// Size returns the total number of occurrences of all values.
+func (m *Multiset[T]) Size() int {
+	return m.length
+}
+
+// Clear removes all values from the multiset.
+func (m *Multiset[T]) Clear() {
+	m.counts.Clear()
+	m.length = 0
+}
+
+// Each calls 'fn' on every distinct value with its count, in no particular order.
+func (m *Multiset[T]) Each(fn func(val T, count int)) {
+	m.counts.Each(fn)
+}
+
+// MostCommon returns up to 'k' of the most common values, ordered from the most common one.
+// Values with equal counts are in no particular order.
+func (m *Multiset[T]) MostCommon(k int) []Entry[T] {
+	entries := make([]Entry[T], 0, m.Distinct())
+
+	m.Each(func(val T, count int) {
+		entries = append(entries, Entry[T]{Value: val, Count: count})
+	})
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Count > entries[j].Count
+	})
+
+	return entries[:util.Clamp(k, 0, len(entries))]
+}
+
+// Union returns a new multiset where each value occurs as many times as it does in the multiset where it occurs more.
+func (m *Multiset[T]) Union(other *Multiset[T]) *Multiset[T] {
+	result := m.Copy()
+
+	other.Each(func(val T, count int) {
+		result.Add(val, count-result.Count(val))
+	})
+
+	return result
+}
+
+// Intersect returns a new multiset where each value occurs as many times as it does in the multiset where it occurs less.
+func (m *Multiset[T]) Intersect(other *Multiset[T]) *Multiset[T] {
+	result := New(uint64(util.Min(m.Distinct(), other.Distinct())), m.equals, m.hash)
+
+	m.Each(func(val T, count int) {
+		result.Add(val, util.Min(count, other.Count(val)))
+	})
+
+	return result
+}
+
+// Sum returns a new multiset where each value occurs as many times as it does in both multisets combined.
+func (m *Multiset[T]) Sum(other *Multiset[T]) *Multiset[T] {
+	result := m.Copy()
+
+	other.Each(func(val T, count int) {
+		result.Add(val, count)
+	})
+
+	return result
+}
+
+// Copy returns a copy of this multiset. Same as with hashmap.Map, the copy will not allocate any memory until the first write.
+func (m *Multiset[T]) Copy() *Multiset[T] {
+	return &Multiset[T]{
+		counts: m.counts.Copy(),
+		length: m.length,
+		equals: m.equals,
+		hash:   m.hash,
+	}
+}
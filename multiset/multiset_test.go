@@ -0,0 +1,98 @@
+package multiset_test
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/igorroncevic/go-utils/multiset"
+	"github.com/igorroncevic/go-utils/util"
+)
+
+func fromCounts(counts map[string]int) *multiset.Multiset[string] {
+	m := multiset.New(0, util.Equals[string], util.HashString)
+	for val, count := range counts {
+		m.Add(val, count)
+	}
+
+	return m
+}
+
+func toCounts(m *multiset.Multiset[string]) map[string]int {
+	counts := map[string]int{}
+
+	m.Each(func(val string, count int) {
+		counts[val] = count
+	})
+
+	return counts
+}
+
+func TestMultiset(t *testing.T) {
+	m := multiset.New(0, util.Equals[string], util.HashString)
+
+	m.Add("foo", 3)
+	m.Add("bar", 1)
+	m.Add("foo", 2)
+	m.Add("baz", 0)
+
+	assert.Equal(t, 5, m.Count("foo"))
+	assert.Equal(t, 0, m.Count("baz"))
+	assert.False(t, m.Contains("baz"))
+	assert.Equal(t, 2, m.Distinct())
+	assert.Equal(t, 6, m.Size())
+
+	assert.Equal(t, 2, m.Remove("foo", 2))
+	assert.Equal(t, 3, m.Count("foo"))
+
+	// Only the remaining occurrences are removed
+	assert.Equal(t, 1, m.Remove("bar", 5))
+	assert.False(t, m.Contains("bar"))
+	assert.Equal(t, 1, m.Distinct())
+	assert.Equal(t, 3, m.Size())
+
+	assert.Equal(t, 0, m.Remove("qux", 1))
+	assert.Equal(t, 0, m.Remove("foo", -1))
+
+	m.Clear()
+	assert.Equal(t, 0, m.Size())
+	assert.Equal(t, 0, m.Distinct())
+}
+
+func TestMultisetMostCommon(t *testing.T) {
+	m := fromCounts(map[string]int{"a": 1, "b": 5, "c": 3, "d": 4})
+
+	assert.Equal(t, []multiset.Entry[string]{{Value: "b", Count: 5}, {Value: "d", Count: 4}}, m.MostCommon(2))
+	assert.Equal(t, 4, len(m.MostCommon(10)))
+	assert.Equal(t, []multiset.Entry[string]{}, m.MostCommon(0))
+}
+
+func TestMultisetAlgebra(t *testing.T) {
+	a := fromCounts(map[string]int{"x": 3, "y": 1})
+	b := fromCounts(map[string]int{"x": 1, "y": 2, "z": 4})
+
+	union := a.Union(b)
+	assert.Equal(t, map[string]int{"x": 3, "y": 2, "z": 4}, toCounts(union))
+	assert.Equal(t, 9, union.Size())
+
+	intersection := a.Intersect(b)
+	assert.Equal(t, map[string]int{"x": 1, "y": 1}, toCounts(intersection))
+	assert.Equal(t, 2, intersection.Size())
+
+	sum := a.Sum(b)
+	assert.Equal(t, map[string]int{"x": 4, "y": 3, "z": 4}, toCounts(sum))
+	assert.Equal(t, 11, sum.Size())
+
+	// Operands are not modified
+	assert.Equal(t, map[string]int{"x": 3, "y": 1}, toCounts(a))
+	assert.Equal(t, map[string]int{"x": 1, "y": 2, "z": 4}, toCounts(b))
+}
+
+func TestMultisetCustomEquality(t *testing.T) {
+	m := multiset.New(0, func(a, b []byte) bool { return string(a) == string(b) }, util.HashBytes)
+
+	m.Add([]byte("foo"), 1)
+	m.Add([]byte("foo"), 1)
+
+	assert.Equal(t, 2, m.Count([]byte("foo")))
+	assert.Equal(t, 1, m.Distinct())
+}
@@ -0,0 +1,148 @@
+package queue
+
+import (
+	"fmt"
+	"sync"
+)
+
+var (
+	ErrFull = fmt.Errorf("queue is full")
+)
+
+// OverflowPolicy determines what happens when an item is enqueued into a full BoundedQueue.
+type OverflowPolicy int
+
+const (
+	// Reject makes Enqueue return ErrFull.
+	Reject OverflowPolicy = iota
+	// DropOldest removes the item at the front of the queue to make room for the new one.
+	DropOldest
+	// DropNewest discards the enqueued item.
+	DropNewest
+	// Block makes Enqueue wait until another goroutine dequeues an item.
+	Block
+)
+
+// BoundedQueue is a queue with a fixed capacity, which is safe for concurrent use.
+// Items are kept in a ring buffer, so both ends of the queue are O(1).
+type BoundedQueue[T any] struct {
+	mu      sync.Mutex
+	notFull *sync.Cond
+
+	elems   []T
+	front   int
+	length  int
+	dropped uint64
+
+	policy OverflowPolicy
+}
+
+func NewBounded[T any](capacity int, policy OverflowPolicy) *BoundedQueue[T] {
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	q := &BoundedQueue[T]{
+		elems:  make([]T, capacity),
+		policy: policy,
+	}
+	q.notFull = sync.NewCond(&q.mu)
+
+	return q
+}
+
+// Enqueue puts the item at the back of the queue, handling a full queue according to its policy.
+func (q *BoundedQueue[T]) Enqueue(val T) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.length == len(q.elems) {
+		switch q.policy {
+		case Reject:
+			q.dropped++
+			return ErrFull
+		case DropNewest:
+			q.dropped++
+			return nil
+		case DropOldest:
+			q.popFront()
+			q.dropped++
+		case Block:
+			for q.length == len(q.elems) {
+				q.notFull.Wait()
+			}
+		}
+	}
+
+	q.elems[(q.front+q.length)%len(q.elems)] = val
+	q.length++
+
+	return nil
+}
+
+// Dequeue returns the item at the front of the queue and removes it from the queue.
+func (q *BoundedQueue[T]) Dequeue() (*T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.length == 0 {
+		return nil, fmt.Errorf("queue is empty")
+	}
+
+	element := q.popFront()
+
+	q.notFull.Signal()
+
+	return &element, nil
+}
+
+// Peek returns a copy of the item at the front of the queue without removing it.
+func (q *BoundedQueue[T]) Peek() (*T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.length == 0 {
+		return nil, fmt.Errorf("queue is empty")
+	}
+
+	element := q.elems[q.front]
+
+	return &element, nil
+}
+
+func (q *BoundedQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.length
+}
+
+// IsEmpty: check if queue is empty
+func (q *BoundedQueue[T]) IsEmpty() bool {
+	return q.Len() == 0
+}
+
+// Cap returns the maximum number of items the queue can hold.
+func (q *BoundedQueue[T]) Cap() int {
+	return len(q.elems)
+}
+
+// Dropped returns the number of items that were rejected, discarded or evicted because the queue was full.
+func (q *BoundedQueue[T]) Dropped() uint64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.dropped
+}
+
+// popFront removes the item at the front of the queue, which must not be empty.
+func (q *BoundedQueue[T]) popFront() T {
+	var empty T
+
+	element := q.elems[q.front]
+	q.elems[q.front] = empty
+	q.front = (q.front + 1) % len(q.elems)
+	q.length--
+
+	return element
+}
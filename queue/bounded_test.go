@@ -0,0 +1,79 @@
+package queue_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert"
+	"github.com/igorroncevic/go-utils/queue"
+)
+
+func fillBounded(t *testing.T, policy queue.OverflowPolicy) *queue.BoundedQueue[int] {
+	q := queue.NewBounded[int](3, policy)
+
+	for i := 1; i <= 3; i++ {
+		assert.NoError(t, q.Enqueue(i))
+	}
+
+	return q
+}
+
+func dequeueAll(t *testing.T, q *queue.BoundedQueue[int]) []int {
+	dequeued := []int{}
+
+	for !q.IsEmpty() {
+		val, err := q.Dequeue()
+		assert.NoError(t, err)
+
+		dequeued = append(dequeued, *val)
+	}
+
+	return dequeued
+}
+
+func TestBoundedQueuePolicies(t *testing.T) {
+	rejecting := fillBounded(t, queue.Reject)
+	assert.Equal(t, queue.ErrFull, rejecting.Enqueue(4))
+	assert.Equal(t, uint64(1), rejecting.Dropped())
+	assert.Equal(t, []int{1, 2, 3}, dequeueAll(t, rejecting))
+
+	droppingOldest := fillBounded(t, queue.DropOldest)
+	assert.NoError(t, droppingOldest.Enqueue(4))
+	assert.NoError(t, droppingOldest.Enqueue(5))
+	assert.Equal(t, uint64(2), droppingOldest.Dropped())
+	assert.Equal(t, 3, droppingOldest.Len())
+	assert.Equal(t, []int{3, 4, 5}, dequeueAll(t, droppingOldest))
+
+	droppingNewest := fillBounded(t, queue.DropNewest)
+	assert.NoError(t, droppingNewest.Enqueue(4))
+	assert.Equal(t, uint64(1), droppingNewest.Dropped())
+	assert.Equal(t, []int{1, 2, 3}, dequeueAll(t, droppingNewest))
+
+	_, err := droppingNewest.Dequeue()
+	assert.Error(t, err)
+}
+
+func TestBoundedQueueBlock(t *testing.T) {
+	q := fillBounded(t, queue.Block)
+	enqueued := make(chan struct{})
+
+	go func() {
+		assert.NoError(t, q.Enqueue(4))
+		close(enqueued)
+	}()
+
+	select {
+	case <-enqueued:
+		t.Fatal("enqueue did not block on a full queue")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	front, err := q.Dequeue()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, *front)
+
+	<-enqueued
+
+	assert.Equal(t, []int{2, 3, 4}, dequeueAll(t, q))
+	assert.Equal(t, uint64(0), q.Dropped())
+}
@@ -0,0 +1,76 @@
+package queue
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+
+	"github.com/igorroncevic/go-utils/internal/encode"
+)
+
+// MarshalJSON encodes the queue as an array, starting with the item at the front of the queue.
+func (q *Queue[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(q.frontToBack())
+}
+
+// UnmarshalJSON replaces the contents of the queue with the decoded items,
+// where the first item ends up at the front of the queue.
+func (q *Queue[T]) UnmarshalJSON(data []byte) error {
+	var values []T
+
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	q.load(values)
+
+	return nil
+}
+
+// MarshalBinary encodes the queue items using gob, starting with the item at the front of the queue.
+func (q *Queue[T]) MarshalBinary() ([]byte, error) {
+	return encode.Gob(q.frontToBack())
+}
+
+// UnmarshalBinary replaces the contents of the queue with the items encoded by MarshalBinary.
+func (q *Queue[T]) UnmarshalBinary(data []byte) error {
+	var values []T
+
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&values); err != nil {
+		return err
+	}
+
+	q.load(values)
+
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder.
+func (q *Queue[T]) GobEncode() ([]byte, error) {
+	return q.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder.
+func (q *Queue[T]) GobDecode(data []byte) error {
+	return q.UnmarshalBinary(data)
+}
+
+// frontToBack returns the items in the order they would be dequeued.
+func (q *Queue[T]) frontToBack() []T {
+	values := make([]T, q.Len())
+
+	for i := range values {
+		values[i] = q.elems[q.Len()-1-i]
+	}
+
+	return values
+}
+
+// load resets the queue and fills it with items ordered from front to back.
+func (q *Queue[T]) load(values []T) {
+	q.elems = make([]T, len(values))
+
+	for i, val := range values {
+		q.elems[len(values)-1-i] = val
+	}
+}
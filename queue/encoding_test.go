@@ -0,0 +1,43 @@
+package queue_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/igorroncevic/go-utils/queue"
+)
+
+func TestQueueJSON(t *testing.T) {
+	q := queue.New[int]()
+	q.Enqueue(1)
+	q.Enqueue(2)
+	q.Enqueue(3)
+
+	data, err := json.Marshal(q)
+	assert.NoError(t, err)
+	assert.Equal(t, `[1,2,3]`, string(data))
+
+	decoded := queue.New[int]()
+	assert.NoError(t, json.Unmarshal(data, decoded))
+
+	front, err := decoded.Dequeue()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, *front)
+	assert.Equal(t, 2, decoded.Len())
+}
+
+func TestQueueGob(t *testing.T) {
+	q := queue.New[string]()
+	q.Enqueue("foo")
+	q.Enqueue("bar")
+
+	var buf bytes.Buffer
+	assert.NoError(t, gob.NewEncoder(&buf).Encode(q))
+
+	decoded := queue.New[string]()
+	assert.NoError(t, gob.NewDecoder(&buf).Decode(decoded))
+	assert.Equal(t, q, decoded)
+}
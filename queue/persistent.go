@@ -0,0 +1,156 @@
+package queue
+
+import (
+	"fmt"
+	"sync"
+)
+
+// PersistentQueue is an immutable banker's queue, where Enqueue and Dequeue return a new version
+// in O(1) amortised time, sharing structure with the version it was made from.
+//
+// Items are dequeued from a lazily evaluated front list and enqueued onto a rear list. Once the rear
+// grows longer than the front, the reversed rear is lazily appended to the front. Thanks to laziness
+// and memoization, the cost of the reversal is paid at most once, even if old versions are reused.
+type PersistentQueue[T any] struct {
+	front    *stream[T]
+	frontLen int
+	rear     *cons[T]
+	rearLen  int
+}
+
+// cons is a node of an immutable singly linked-list.
+type cons[T any] struct {
+	value T
+	next  *cons[T]
+}
+
+// stream is a lazily evaluated immutable list, whose cells are computed once, when first needed.
+// A nil stream is empty.
+type stream[T any] struct {
+	once  sync.Once
+	thunk func() *cell[T]
+	cell  *cell[T]
+}
+
+type cell[T any] struct {
+	value T
+	next  *stream[T]
+}
+
+func NewPersistent[T any]() *PersistentQueue[T] {
+	return &PersistentQueue[T]{}
+}
+
+// Enqueue returns a new version of the queue with the item at the back.
+func (q *PersistentQueue[T]) Enqueue(val T) *PersistentQueue[T] {
+	return balanced(q.front, q.frontLen, &cons[T]{value: val, next: q.rear}, q.rearLen+1)
+}
+
+// Dequeue returns the item at the front of the queue and a new version of the queue without it.
+func (q *PersistentQueue[T]) Dequeue() (*T, *PersistentQueue[T], error) {
+	if q.IsEmpty() {
+		return nil, q, fmt.Errorf("queue is empty")
+	}
+
+	front := q.front.force()
+	element := front.value
+
+	return &element, balanced(front.next, q.frontLen-1, q.rear, q.rearLen), nil
+}
+
+// Peek returns the item at the front of the queue.
+func (q *PersistentQueue[T]) Peek() (*T, error) {
+	if q.IsEmpty() {
+		return nil, fmt.Errorf("queue is empty")
+	}
+
+	element := q.front.force().value
+
+	return &element, nil
+}
+
+func (q *PersistentQueue[T]) Len() int {
+	return q.frontLen + q.rearLen
+}
+
+// IsEmpty: check if queue is empty
+func (q *PersistentQueue[T]) IsEmpty() bool {
+	return q.Len() == 0
+}
+
+// Each calls 'fn' on every item, starting with the one at the front of the queue.
+func (q *PersistentQueue[T]) Each(fn func(val T)) {
+	for c := q.front.force(); c != nil; c = c.next.force() {
+		fn(c.value)
+	}
+
+	rear := make([]T, 0, q.rearLen)
+	for node := q.rear; node != nil; node = node.next {
+		rear = append(rear, node.value)
+	}
+
+	for i := len(rear) - 1; i >= 0; i-- {
+		fn(rear[i])
+	}
+}
+
+// balanced builds a queue, moving the rear to the end of the front once it grows longer than the front.
+// This keeps the front non-empty for as long as the queue is non-empty.
+func balanced[T any](front *stream[T], frontLen int, rear *cons[T], rearLen int) *PersistentQueue[T] {
+	if rearLen <= frontLen {
+		return &PersistentQueue[T]{front: front, frontLen: frontLen, rear: rear, rearLen: rearLen}
+	}
+
+	return &PersistentQueue[T]{
+		front:    appendStream(front, reverse(rear)),
+		frontLen: frontLen + rearLen,
+	}
+}
+
+// force evaluates the stream's first cell, or returns nil if the stream is empty.
+func (s *stream[T]) force() *cell[T] {
+	if s == nil {
+		return nil
+	}
+
+	s.once.Do(func() {
+		s.cell = s.thunk()
+		s.thunk = nil
+	})
+
+	return s.cell
+}
+
+// appendStream lazily appends 'b' to 'a', one cell at a time.
+func appendStream[T any](a, b *stream[T]) *stream[T] {
+	return &stream[T]{thunk: func() *cell[T] {
+		first := a.force()
+		if first == nil {
+			return b.force()
+		}
+
+		return &cell[T]{value: first.value, next: appendStream(first.next, b)}
+	}}
+}
+
+// reverse lazily reverses the list, which is done as a whole once the stream is first forced.
+func reverse[T any](list *cons[T]) *stream[T] {
+	return &stream[T]{thunk: func() *cell[T] {
+		var reversed *cell[T]
+
+		for node := list; node != nil; node = node.next {
+			reversed = &cell[T]{value: node.value, next: evaluated(reversed)}
+		}
+
+		return reversed
+	}}
+}
+
+// evaluated wraps an already computed cell into a stream.
+func evaluated[T any](c *cell[T]) *stream[T] {
+	if c == nil {
+		return nil
+	}
+
+	return &stream[T]{thunk: func() *cell[T] { return c }}
+}
@@ -0,0 +1,83 @@
+package queue_test
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/igorroncevic/go-utils/queue"
+)
+
+func dequeuePersistent(t *testing.T, q *queue.PersistentQueue[int]) []int {
+	dequeued := []int{}
+
+	for !q.IsEmpty() {
+		val, next, err := q.Dequeue()
+		assert.NoError(t, err)
+
+		dequeued = append(dequeued, *val)
+		q = next
+	}
+
+	return dequeued
+}
+
+func TestPersistentQueue(t *testing.T) {
+	empty := queue.NewPersistent[int]()
+	q := empty
+
+	for i := 1; i <= 5; i++ {
+		q = q.Enqueue(i)
+	}
+
+	assert.Equal(t, 5, q.Len())
+	assert.True(t, empty.IsEmpty())
+
+	front, err := q.Peek()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, *front)
+
+	// Same version can be dequeued from multiple times
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, dequeuePersistent(t, q))
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, dequeuePersistent(t, q))
+
+	// Branching off an older version
+	_, shorter, err := q.Dequeue()
+	assert.NoError(t, err)
+
+	branchA := shorter.Enqueue(6)
+	branchB := shorter.Enqueue(7).Enqueue(8)
+
+	assert.Equal(t, []int{2, 3, 4, 5, 6}, dequeuePersistent(t, branchA))
+	assert.Equal(t, []int{2, 3, 4, 5, 7, 8}, dequeuePersistent(t, branchB))
+
+	values := []int{}
+	branchB.Each(func(val int) {
+		values = append(values, val)
+	})
+
+	assert.Equal(t, []int{2, 3, 4, 5, 7, 8}, values)
+
+	_, _, err = empty.Dequeue()
+	assert.Error(t, err)
+}
+
+func TestPersistentQueueInterleaved(t *testing.T) {
+	q := queue.NewPersistent[int]()
+	expected := []int{}
+
+	for i := 0; i < 100; i++ {
+		q = q.Enqueue(i)
+		expected = append(expected, i)
+
+		if i%3 == 0 {
+			val, next, err := q.Dequeue()
+			assert.NoError(t, err)
+			assert.Equal(t, expected[0], *val)
+
+			q = next
+			expected = expected[1:]
+		}
+	}
+
+	assert.Equal(t, expected, dequeuePersistent(t, q))
+}
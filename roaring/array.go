@@ -0,0 +1,146 @@
+package roaring
+
+import "sort"
+
+// arrayContainer stores sparse values as a sorted slice.
+type arrayContainer struct {
+	values []uint16
+}
+
+func (a *arrayContainer) add(x uint16) container {
+	i, found := a.find(x)
+	if found {
+		return a
+	}
+
+	if len(a.values) >= arrayMaxSize {
+		return toBitmap(a).add(x)
+	}
+
+	a.values = append(a.values, 0)
+	copy(a.values[i+1:], a.values[i:])
+	a.values[i] = x
+
+	return a
+}
+
+func (a *arrayContainer) remove(x uint16) container {
+	if i, found := a.find(x); found {
+		a.values = append(a.values[:i], a.values[i+1:]...)
+	}
+
+	return a
+}
+
+func (a *arrayContainer) contains(x uint16) bool {
+	_, found := a.find(x)
+	return found
+}
+
+func (a *arrayContainer) cardinality() int {
+	return len(a.values)
+}
+
+func (a *arrayContainer) rank(x uint16) int {
+	return sort.Search(len(a.values), func(i int) bool {
+		return a.values[i] > x
+	})
+}
+
+func (a *arrayContainer) selectAt(i int) uint16 {
+	return a.values[i]
+}
+
+func (a *arrayContainer) each(fn func(x uint16) bool) bool {
+	for _, x := range a.values {
+		if shouldStop := fn(x); shouldStop {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (a *arrayContainer) numRuns() int {
+	var runs int
+
+	for i, x := range a.values {
+		if i == 0 || a.values[i-1]+1 != x {
+			runs++
+		}
+	}
+
+	return runs
+}
+
+func (a *arrayContainer) clone() container {
+	values := make([]uint16, len(a.values))
+	copy(values, a.values)
+
+	return &arrayContainer{values}
+}
+
+// find returns the index of the first value greater than or equal to 'x', and whether it is equal.
+func (a *arrayContainer) find(x uint16) (int, bool) {
+	i := sort.Search(len(a.values), func(i int) bool {
+		return a.values[i] >= x
+	})
+
+	return i, i < len(a.values) && a.values[i] == x
+}
+
+// filter returns a new container with the values for which 'keep' returns true.
+func (a *arrayContainer) filter(keep func(x uint16) bool) container {
+	result := &arrayContainer{values: make([]uint16, 0, len(a.values))}
+
+	for _, x := range a.values {
+		if keep(x) {
+			result.values = append(result.values, x)
+		}
+	}
+
+	return result
+}
+
+// merge walks both containers in order, keeping the values that are only in this one,
+// only in the other one, or in both, as requested.
+func (a *arrayContainer) merge(other *arrayContainer, onlyA, both, onlyB bool) *arrayContainer {
+	var (
+		result = &arrayContainer{values: make([]uint16, 0, len(a.values)+len(other.values))}
+		i, j   int
+	)
+
+	for i < len(a.values) && j < len(other.values) {
+		switch x, y := a.values[i], other.values[j]; {
+		case x < y:
+			if onlyA {
+				result.values = append(result.values, x)
+			}
+
+			i++
+		case x > y:
+			if onlyB {
+				result.values = append(result.values, y)
+			}
+
+			j++
+		default:
+			if both {
+				result.values = append(result.values, x)
+			}
+
+			i++
+			j++
+		}
+	}
+
+	if onlyA {
+		result.values = append(result.values, a.values[i:]...)
+	}
+
+	if onlyB {
+		result.values = append(result.values, other.values[j:]...)
+	}
+
+	return result
+}
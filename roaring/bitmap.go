@@ -0,0 +1,111 @@
+package roaring
+
+import "math/bits"
+
+// bitmapContainer stores dense values as one bit per value.
+type bitmapContainer struct {
+	words []uint64
+	card  int
+}
+
+func newBitmapContainer() *bitmapContainer {
+	return &bitmapContainer{
+		words: make([]uint64, bitmapWords),
+	}
+}
+
+func (b *bitmapContainer) add(x uint16) container {
+	if !b.contains(x) {
+		b.words[x/64] |= 1 << (x % 64)
+		b.card++
+	}
+
+	return b
+}
+
+func (b *bitmapContainer) remove(x uint16) container {
+	if !b.contains(x) {
+		return b
+	}
+
+	b.words[x/64] &^= 1 << (x % 64)
+	b.card--
+
+	return normalize(b)
+}
+
+func (b *bitmapContainer) contains(x uint16) bool {
+	return b.words[x/64]&(1<<(x%64)) != 0
+}
+
+func (b *bitmapContainer) cardinality() int {
+	return b.card
+}
+
+func (b *bitmapContainer) rank(x uint16) int {
+	var rank int
+
+	for _, word := range b.words[:x/64] {
+		rank += bits.OnesCount64(word)
+	}
+
+	// Keeps the bits up to and including 'x', the shift wraps around to all ones when 'x' is the last bit
+	mask := uint64(2)<<(x%64) - 1
+
+	return rank + bits.OnesCount64(b.words[x/64]&mask)
+}
+
+func (b *bitmapContainer) selectAt(i int) uint16 {
+	for idx, word := range b.words {
+		count := bits.OnesCount64(word)
+		if i >= count {
+			i -= count
+			continue
+		}
+
+		// Drop the lowest set bits until the wanted one is the lowest
+		for ; i > 0; i-- {
+			word &= word - 1
+		}
+
+		return uint16(idx*64 + bits.TrailingZeros64(word))
+	}
+
+	return 0
+}
+
+func (b *bitmapContainer) each(fn func(x uint16) bool) bool {
+	for idx, word := range b.words {
+		for word != 0 {
+			if shouldStop := fn(uint16(idx*64 + bits.TrailingZeros64(word))); shouldStop {
+				return true
+			}
+
+			word &= word - 1
+		}
+	}
+
+	return false
+}
+
+func (b *bitmapContainer) numRuns() int {
+	var (
+		runs int
+		prev uint64
+	)
+
+	for _, word := range b.words {
+		// A run starts at every set bit whose previous bit, possibly from the previous word, is clear
+		runs += bits.OnesCount64(word &^ (word<<1 | prev>>63))
+		prev = word
+	}
+
+	return runs
+}
+
+func (b *bitmapContainer) clone() container {
+	words := make([]uint64, len(b.words))
+	copy(words, b.words)
+
+	return &bitmapContainer{words: words, card: b.card}
+}
@@ -0,0 +1,179 @@
+package roaring
+
+import "math/bits"
+
+const (
+	// arrayMaxSize is the largest cardinality kept in an array container,
+	// above which a bitmap container takes less space.
+	arrayMaxSize = 4096
+
+	bitmapWords = 1 << 16 / 64
+
+	// maxRuns is the largest number of runs for which a run container is smaller than a bitmap container.
+	maxRuns = (bitmapWords*8 - 2) / 4
+)
+
+// container holds the lower 16 bits of all values that share the same upper 16 bits.
+// Writes return the container that should replace the receiver, since it may change its kind.
+type container interface {
+	add(x uint16) container
+	remove(x uint16) container
+	contains(x uint16) bool
+	cardinality() int
+	// rank returns the number of values less than or equal to 'x'.
+	rank(x uint16) int
+	// selectAt returns the value with the given 0-based rank, which must be in range.
+	selectAt(i int) uint16
+	// each calls 'fn' on every value in ascending order and returns true if the iteration was stopped.
+	each(fn func(x uint16) bool) bool
+	// numRuns returns the number of runs of consecutive values.
+	numRuns() int
+	clone() container
+}
+
+func and(a, b container) container {
+	if arr, ok := a.(*arrayContainer); ok {
+		return arr.filter(b.contains)
+	}
+
+	if arr, ok := b.(*arrayContainer); ok {
+		return arr.filter(a.contains)
+	}
+
+	return wordOp(a, b, func(x, y uint64) uint64 { return x & y })
+}
+
+func or(a, b container) container {
+	arrA, okA := a.(*arrayContainer)
+	arrB, okB := b.(*arrayContainer)
+
+	if okA && okB {
+		return normalize(arrA.merge(arrB, true, true, true))
+	}
+
+	return wordOp(a, b, func(x, y uint64) uint64 { return x | y })
+}
+
+func xor(a, b container) container {
+	arrA, okA := a.(*arrayContainer)
+	arrB, okB := b.(*arrayContainer)
+
+	if okA && okB {
+		return normalize(arrA.merge(arrB, true, false, true))
+	}
+
+	return wordOp(a, b, func(x, y uint64) uint64 { return x ^ y })
+}
+
+func andNot(a, b container) container {
+	if arr, ok := a.(*arrayContainer); ok {
+		return arr.filter(func(x uint16) bool { return !b.contains(x) })
+	}
+
+	return wordOp(a, b, func(x, y uint64) uint64 { return x &^ y })
+}
+
+// wordOp combines both containers a whole 64-bit word at a time.
+func wordOp(a, b container, op func(x, y uint64) uint64) container {
+	wordsA, wordsB := toBitmap(a).words, toBitmap(b).words
+	result := newBitmapContainer()
+
+	for i := range result.words {
+		result.words[i] = op(wordsA[i], wordsB[i])
+		result.card += bits.OnesCount64(result.words[i])
+	}
+
+	return normalize(result)
+}
+
+// normalize converts array and bitmap containers into whichever of the two is the right one for their cardinality.
+func normalize(c container) container {
+	switch c := c.(type) {
+	case *arrayContainer:
+		if c.cardinality() > arrayMaxSize {
+			return toBitmap(c)
+		}
+	case *bitmapContainer:
+		if c.cardinality() <= arrayMaxSize {
+			return toArray(c)
+		}
+	}
+
+	return c
+}
+
+// optimize converts the container into whichever kind takes up the least space.
+// Run containers are only picked when they are strictly smaller.
+func optimize(c container) container {
+	var (
+		card     = c.cardinality()
+		runSize  = 2 + 4*c.numRuns()
+		restSize = 2 * card
+	)
+
+	if card > arrayMaxSize {
+		restSize = bitmapWords * 8
+	}
+
+	if runSize < restSize {
+		return toRun(c)
+	}
+
+	if card > arrayMaxSize {
+		return toBitmap(c)
+	}
+
+	return toArray(c)
+}
+
+func toArray(c container) *arrayContainer {
+	if arr, ok := c.(*arrayContainer); ok {
+		return arr
+	}
+
+	arr := &arrayContainer{values: make([]uint16, 0, c.cardinality())}
+
+	c.each(func(x uint16) bool {
+		arr.values = append(arr.values, x)
+		return false
+	})
+
+	return arr
+}
+
+func toBitmap(c container) *bitmapContainer {
+	if bm, ok := c.(*bitmapContainer); ok {
+		return bm
+	}
+
+	bm := newBitmapContainer()
+
+	c.each(func(x uint16) bool {
+		bm.words[x/64] |= 1 << (x % 64)
+		return false
+	})
+
+	bm.card = c.cardinality()
+
+	return bm
+}
+
+func toRun(c container) *runContainer {
+	if run, ok := c.(*runContainer); ok {
+		return run
+	}
+
+	run := &runContainer{runs: make([]interval, 0, c.numRuns())}
+
+	c.each(func(x uint16) bool {
+		if last := len(run.runs) - 1; last >= 0 && run.runs[last].end+1 == x {
+			run.runs[last].end = x
+		} else {
+			run.runs = append(run.runs, interval{start: x, end: x})
+		}
+
+		return false
+	})
+
+	return run
+}
@@ -0,0 +1,305 @@
+// Package roaring implements Roaring bitmaps, compressed sets of uint32 values.
+//
+// Values are split into chunks by their upper 16 bits, and each chunk keeps its lower 16 bits in whichever
+// container suits it best: a sorted array when sparse, a bitmap when dense, or a list of runs of
+// consecutive values after RunOptimize. See https://roaringbitmap.org for details.
+package roaring
+
+import "sort"
+
+// Bitmap is a compressed set of uint32 values.
+type Bitmap struct {
+	keys       []uint16
+	containers []container
+}
+
+// New constructs a new, empty bitmap.
+func New() *Bitmap {
+	return &Bitmap{}
+}
+
+// FromSlice constructs a new bitmap with the given values.
+func FromSlice(values []uint32) *Bitmap {
+	b := New()
+	for _, x := range values {
+		b.Add(x)
+	}
+
+	return b
+}
+
+// Add adds 'x' to the bitmap.
+func (b *Bitmap) Add(x uint32) {
+	hi, lo := split(x)
+
+	i, found := b.find(hi)
+	if !found {
+		b.insertAt(i, hi, &arrayContainer{})
+	}
+
+	b.containers[i] = b.containers[i].add(lo)
+}
+
+// Remove removes 'x' from the bitmap.
+func (b *Bitmap) Remove(x uint32) {
+	hi, lo := split(x)
+
+	i, found := b.find(hi)
+	if !found {
+		return
+	}
+
+	b.containers[i] = b.containers[i].remove(lo)
+	if b.containers[i].cardinality() == 0 {
+		b.keys = append(b.keys[:i], b.keys[i+1:]...)
+		b.containers = append(b.containers[:i], b.containers[i+1:]...)
+	}
+}
+
+// Contains returns whether 'x' is in the bitmap.
+func (b *Bitmap) Contains(x uint32) bool {
+	hi, lo := split(x)
+
+	i, found := b.find(hi)
+
+	return found && b.containers[i].contains(lo)
+}
+
+// Cardinality returns the number of values in the bitmap.
+func (b *Bitmap) Cardinality() uint64 {
+	var card uint64
+
+	for _, c := range b.containers {
+		card += uint64(c.cardinality())
+	}
+
+	return card
+}
+
+// IsEmpty returns whether the bitmap has no values.
+func (b *Bitmap) IsEmpty() bool {
+	return len(b.containers) == 0
+}
+
+// Rank returns the number of values in the bitmap that are less than or equal to 'x'.
+func (b *Bitmap) Rank(x uint32) uint64 {
+	var rank uint64
+
+	hi, lo := split(x)
+
+	for i, key := range b.keys {
+		if key > hi {
+			break
+		}
+
+		if key < hi {
+			rank += uint64(b.containers[i].cardinality())
+		} else {
+			rank += uint64(b.containers[i].rank(lo))
+		}
+	}
+
+	return rank
+}
+
+// Select returns the value with the given 0-based rank, or false if the rank is out of range.
+func (b *Bitmap) Select(rank uint64) (uint32, bool) {
+	for i, c := range b.containers {
+		card := uint64(c.cardinality())
+		if rank < card {
+			return join(b.keys[i], c.selectAt(int(rank))), true
+		}
+
+		rank -= card
+	}
+
+	return 0, false
+}
+
+// Minimum returns the smallest value in the bitmap, or false if it is empty.
+func (b *Bitmap) Minimum() (uint32, bool) {
+	if b.IsEmpty() {
+		return 0, false
+	}
+
+	return join(b.keys[0], b.containers[0].selectAt(0)), true
+}
+
+// Maximum returns the largest value in the bitmap, or false if it is empty.
+func (b *Bitmap) Maximum() (uint32, bool) {
+	if b.IsEmpty() {
+		return 0, false
+	}
+
+	last := len(b.containers) - 1
+
+	return join(b.keys[last], b.containers[last].selectAt(b.containers[last].cardinality()-1)), true
+}
+
+// Each calls 'fn' on every value in the bitmap in ascending order.
+// Iteration stops as soon as 'fn' returns true.
+func (b *Bitmap) Each(fn func(x uint32) bool) {
+	for i, c := range b.containers {
+		hi := b.keys[i]
+
+		stopped := c.each(func(lo uint16) bool {
+			return fn(join(hi, lo))
+		})
+		if stopped {
+			return
+		}
+	}
+}
+
+// ToSlice returns all of the values in the bitmap in ascending order.
+func (b *Bitmap) ToSlice() []uint32 {
+	values := make([]uint32, 0, b.Cardinality())
+
+	b.Each(func(x uint32) bool {
+		values = append(values, x)
+		return false
+	})
+
+	return values
+}
+
+// And returns a new bitmap with the values that are in both of the bitmaps.
+func (b *Bitmap) And(other *Bitmap) *Bitmap {
+	return b.combine(other, and, false, false)
+}
+
+// Or returns a new bitmap with the values that are in either of the bitmaps.
+func (b *Bitmap) Or(other *Bitmap) *Bitmap {
+	return b.combine(other, or, true, true)
+}
+
+// Xor returns a new bitmap with the values that are in exactly one of the bitmaps.
+func (b *Bitmap) Xor(other *Bitmap) *Bitmap {
+	return b.combine(other, xor, true, true)
+}
+
+// AndNot returns a new bitmap with the values of this bitmap that are not in 'other'.
+func (b *Bitmap) AndNot(other *Bitmap) *Bitmap {
+	return b.combine(other, andNot, true, false)
+}
+
+// Equal returns whether both bitmaps contain the same values.
+func (b *Bitmap) Equal(other *Bitmap) bool {
+	if len(b.keys) != len(other.keys) {
+		return false
+	}
+
+	for i, key := range b.keys {
+		if key != other.keys[i] || b.containers[i].cardinality() != other.containers[i].cardinality() {
+			return false
+		}
+
+		if xor(b.containers[i], other.containers[i]).cardinality() != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Copy returns a copy of this bitmap.
+func (b *Bitmap) Copy() *Bitmap {
+	copy := &Bitmap{
+		keys:       make([]uint16, len(b.keys)),
+		containers: make([]container, len(b.containers)),
+	}
+
+	for i, c := range b.containers {
+		copy.keys[i] = b.keys[i]
+		copy.containers[i] = c.clone()
+	}
+
+	return copy
+}
+
+// RunOptimize converts every container into whichever kind takes up the least space, which is
+// where long runs of consecutive values get compressed. It is worth calling again after many changes.
+func (b *Bitmap) RunOptimize() {
+	for i, c := range b.containers {
+		b.containers[i] = optimize(c)
+	}
+}
+
+// combine merges the containers of both bitmaps by their keys. Containers whose key is only in one of the
+// bitmaps are copied over if requested, while the ones in both are combined with 'op'.
+func (b *Bitmap) combine(other *Bitmap, op func(a, b container) container, keepOnlyB, keepOnlyOther bool) *Bitmap {
+	var (
+		result = New()
+		i, j   int
+	)
+
+	for i < len(b.keys) && j < len(other.keys) {
+		switch hiB, hiOther := b.keys[i], other.keys[j]; {
+		case hiB < hiOther:
+			if keepOnlyB {
+				result.append(hiB, b.containers[i].clone())
+			}
+
+			i++
+		case hiB > hiOther:
+			if keepOnlyOther {
+				result.append(hiOther, other.containers[j].clone())
+			}
+
+			j++
+		default:
+			result.append(hiB, op(b.containers[i], other.containers[j]))
+
+			i++
+			j++
+		}
+	}
+
+	for ; keepOnlyB && i < len(b.keys); i++ {
+		result.append(b.keys[i], b.containers[i].clone())
+	}
+
+	for ; keepOnlyOther && j < len(other.keys); j++ {
+		result.append(other.keys[j], other.containers[j].clone())
+	}
+
+	return result
+}
+
+// append adds a container after all of the existing ones, unless it is empty.
+func (b *Bitmap) append(key uint16, c container) {
+	if c.cardinality() == 0 {
+		return
+	}
+
+	b.keys = append(b.keys, key)
+	b.containers = append(b.containers, c)
+}
+
+func (b *Bitmap) insertAt(i int, key uint16, c container) {
+	b.keys = append(b.keys, 0)
+	copy(b.keys[i+1:], b.keys[i:])
+	b.keys[i] = key
+
+	b.containers = append(b.containers, nil)
+	copy(b.containers[i+1:], b.containers[i:])
+	b.containers[i] = c
+}
+
+// find returns the index of the first key greater than or equal to 'key', and whether it is equal.
+func (b *Bitmap) find(key uint16) (int, bool) {
+	i := sort.Search(len(b.keys), func(i int) bool {
+		return b.keys[i] >= key
+	})
+
+	return i, i < len(b.keys) && b.keys[i] == key
+}
+
+// split separates the value into the key of its container and the part stored in the container.
+func split(x uint32) (uint16, uint16) {
+	return uint16(x >> 16), uint16(x)
+}
+
+func join(hi, lo uint16) uint32 {
+	return uint32(hi)<<16 | uint32(lo)
+}
@@ -0,0 +1,194 @@
+package roaring_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/igorroncevic/go-utils/roaring"
+)
+
+// randomValues returns sorted, distinct values clustered around a few chunks, so that all container kinds are used.
+func randomValues(r *rand.Rand, count int) []uint32 {
+	seen := map[uint32]bool{}
+	values := []uint32{}
+
+	for len(values) < count {
+		var x uint32
+
+		switch r.Intn(3) {
+		case 0: // sparse
+			x = r.Uint32()
+		case 1: // dense
+			x = 1<<16 + uint32(r.Intn(1<<15))
+		default: // runs
+			x = 5<<16 + uint32(r.Intn(100))*1000 + uint32(r.Intn(200))
+		}
+
+		if !seen[x] {
+			seen[x] = true
+			values = append(values, x)
+		}
+	}
+
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+
+	return values
+}
+
+func reference(a, b []uint32, keep func(inA, inB bool) bool) []uint32 {
+	inA, inB := map[uint32]bool{}, map[uint32]bool{}
+	all := map[uint32]bool{}
+
+	for _, x := range a {
+		inA[x], all[x] = true, true
+	}
+
+	for _, x := range b {
+		inB[x], all[x] = true, true
+	}
+
+	values := []uint32{}
+
+	for x := range all {
+		if keep(inA[x], inB[x]) {
+			values = append(values, x)
+		}
+	}
+
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+
+	return values
+}
+
+func TestBitmap(t *testing.T) {
+	b := roaring.New()
+	assert.True(t, b.IsEmpty())
+
+	for _, x := range []uint32{5, 1, 1 << 20, 70000, 1} {
+		b.Add(x)
+	}
+
+	assert.Equal(t, uint64(4), b.Cardinality())
+	assert.True(t, b.Contains(70000))
+	assert.False(t, b.Contains(2))
+	assert.Equal(t, []uint32{1, 5, 70000, 1 << 20}, b.ToSlice())
+
+	min, ok := b.Minimum()
+	assert.True(t, ok)
+	assert.Equal(t, uint32(1), min)
+
+	max, ok := b.Maximum()
+	assert.True(t, ok)
+	assert.Equal(t, uint32(1<<20), max)
+
+	b.Remove(70000)
+	b.Remove(3)
+	assert.Equal(t, []uint32{1, 5, 1 << 20}, b.ToSlice())
+
+	var visited []uint32
+
+	b.Each(func(x uint32) bool {
+		visited = append(visited, x)
+		return x == 5
+	})
+	assert.Equal(t, []uint32{1, 5}, visited)
+}
+
+func TestBitmapDense(t *testing.T) {
+	b := roaring.New()
+
+	// Goes from an array to a bitmap container and back
+	for x := uint32(0); x < 10000; x += 2 {
+		b.Add(x)
+	}
+
+	assert.Equal(t, uint64(5000), b.Cardinality())
+	assert.True(t, b.Contains(9998))
+	assert.False(t, b.Contains(9999))
+
+	for x := uint32(0); x < 4000; x += 2 {
+		b.Remove(x)
+	}
+
+	assert.Equal(t, uint64(3000), b.Cardinality())
+	assert.False(t, b.Contains(0))
+	assert.True(t, b.Contains(4000))
+}
+
+func TestBitmapRankSelect(t *testing.T) {
+	r := rand.New(rand.NewSource(1)) //nolint:gosec // deterministic test data
+	values := randomValues(r, 20000)
+
+	for _, optimize := range []bool{false, true} {
+		b := roaring.FromSlice(values)
+		if optimize {
+			b.RunOptimize()
+		}
+
+		for i, x := range values {
+			assert.Equal(t, uint64(i+1), b.Rank(x))
+
+			selected, ok := b.Select(uint64(i))
+			assert.True(t, ok)
+			assert.Equal(t, x, selected)
+		}
+
+		_, ok := b.Select(uint64(len(values)))
+		assert.False(t, ok)
+		assert.Equal(t, uint64(0), b.Rank(values[0]-1))
+	}
+}
+
+func TestBitmapAlgebra(t *testing.T) {
+	r := rand.New(rand.NewSource(2)) //nolint:gosec // deterministic test data
+	a, b := randomValues(r, 15000), randomValues(r, 15000)
+
+	for _, optimize := range []bool{false, true} {
+		bmA, bmB := roaring.FromSlice(a), roaring.FromSlice(b)
+		if optimize {
+			bmA.RunOptimize()
+			bmB.RunOptimize()
+		}
+
+		assert.Equal(t, reference(a, b, func(inA, inB bool) bool { return inA && inB }), bmA.And(bmB).ToSlice())
+		assert.Equal(t, reference(a, b, func(inA, inB bool) bool { return inA || inB }), bmA.Or(bmB).ToSlice())
+		assert.Equal(t, reference(a, b, func(inA, inB bool) bool { return inA != inB }), bmA.Xor(bmB).ToSlice())
+		assert.Equal(t, reference(a, b, func(inA, inB bool) bool { return inA && !inB }), bmA.AndNot(bmB).ToSlice())
+
+		// Operands are not modified
+		assert.Equal(t, a, bmA.ToSlice())
+		assert.True(t, bmA.Xor(bmB).Xor(bmB).Equal(bmA))
+		assert.False(t, bmA.Equal(bmB))
+	}
+}
+
+func TestBitmapRunOptimize(t *testing.T) {
+	b := roaring.New()
+	for x := uint32(100); x < 60000; x++ {
+		b.Add(x)
+	}
+
+	b.RunOptimize()
+
+	copy := b.Copy()
+
+	// Run containers are modified in place, splitting and merging runs
+	b.Remove(200)
+	b.Remove(100)
+	b.Remove(59999)
+	b.Add(99)
+	b.Add(200)
+	b.Add(59999)
+	b.Add(60001)
+
+	assert.Equal(t, uint64(59901), b.Cardinality())
+	assert.False(t, b.Contains(100))
+	assert.True(t, b.Contains(200))
+	assert.False(t, b.Contains(60000))
+	assert.True(t, b.Contains(60001))
+
+	assert.Equal(t, uint64(59900), copy.Cardinality())
+	assert.True(t, copy.Contains(100))
+}
@@ -0,0 +1,149 @@
+package roaring
+
+import "sort"
+
+// runContainer stores values as sorted runs of consecutive values.
+// Runs never overlap nor touch, since such runs are merged.
+type runContainer struct {
+	runs []interval
+}
+
+// interval is a run of consecutive values, where both ends are inclusive.
+type interval struct {
+	start, end uint16
+}
+
+func (r *runContainer) add(x uint16) container {
+	i := r.find(x)
+	if i > 0 && x <= r.runs[i-1].end {
+		return r
+	}
+
+	var (
+		extendsPrev = i > 0 && r.runs[i-1].end+1 == x
+		extendsNext = i < len(r.runs) && x+1 == r.runs[i].start
+	)
+
+	switch {
+	case extendsPrev && extendsNext:
+		r.runs[i-1].end = r.runs[i].end
+		r.runs = append(r.runs[:i], r.runs[i+1:]...)
+	case extendsPrev:
+		r.runs[i-1].end = x
+	case extendsNext:
+		r.runs[i].start = x
+	default:
+		r.runs = append(r.runs, interval{})
+		copy(r.runs[i+1:], r.runs[i:])
+		r.runs[i] = interval{start: x, end: x}
+	}
+
+	if len(r.runs) > maxRuns {
+		return toBitmap(r)
+	}
+
+	return r
+}
+
+func (r *runContainer) remove(x uint16) container {
+	i := r.find(x) - 1
+	if i < 0 || x > r.runs[i].end {
+		return r
+	}
+
+	run := r.runs[i]
+
+	switch {
+	case run.start == run.end:
+		r.runs = append(r.runs[:i], r.runs[i+1:]...)
+	case x == run.start:
+		r.runs[i].start++
+	case x == run.end:
+		r.runs[i].end--
+	default:
+		// Split the run in two around 'x'
+		r.runs = append(r.runs, interval{})
+		copy(r.runs[i+1:], r.runs[i:])
+		r.runs[i].end = x - 1
+		r.runs[i+1].start = x + 1
+	}
+
+	if len(r.runs) > maxRuns {
+		return toBitmap(r)
+	}
+
+	return r
+}
+
+func (r *runContainer) contains(x uint16) bool {
+	i := r.find(x)
+	return i > 0 && x <= r.runs[i-1].end
+}
+
+func (r *runContainer) cardinality() int {
+	var card int
+
+	for _, run := range r.runs {
+		card += int(run.end-run.start) + 1
+	}
+
+	return card
+}
+
+func (r *runContainer) rank(x uint16) int {
+	var rank int
+
+	for _, run := range r.runs[:r.find(x)] {
+		end := run.end
+		if x < end {
+			end = x
+		}
+
+		rank += int(end-run.start) + 1
+	}
+
+	return rank
+}
+
+func (r *runContainer) selectAt(i int) uint16 {
+	for _, run := range r.runs {
+		length := int(run.end-run.start) + 1
+		if i < length {
+			return run.start + uint16(i)
+		}
+
+		i -= length
+	}
+
+	return 0
+}
+
+func (r *runContainer) each(fn func(x uint16) bool) bool {
+	for _, run := range r.runs {
+		for x := int(run.start); x <= int(run.end); x++ {
+			if shouldStop := fn(uint16(x)); shouldStop {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (r *runContainer) numRuns() int {
+	return len(r.runs)
+}
+
+func (r *runContainer) clone() container {
+	runs := make([]interval, len(r.runs))
+	copy(runs, r.runs)
+
+	return &runContainer{runs}
+}
+
+// find returns the index of the first run that starts after 'x'.
+func (r *runContainer) find(x uint16) int {
+	return sort.Search(len(r.runs), func(i int) bool {
+		return r.runs[i].start > x
+	})
+}
@@ -0,0 +1,257 @@
+package roaring
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+)
+
+// Cookies that open the portable serialization format, which tell whether any of the containers are run containers.
+// See https://github.com/RoaringBitmap/RoaringFormatSpec for the full format.
+const (
+	serialCookieNoRuns = 12346
+	serialCookie       = 12347
+
+	// noOffsetThreshold is the number of containers below which bitmaps with run containers omit the offset header.
+	noOffsetThreshold = 4
+)
+
+var (
+	ErrInvalidData = fmt.Errorf("invalid roaring bitmap data")
+)
+
+// MarshalBinary encodes the bitmap in the portable Roaring format shared by the
+// C, Java and Go implementations, so it can be exchanged with them.
+func (b *Bitmap) MarshalBinary() ([]byte, error) {
+	var (
+		n       = len(b.containers)
+		hasRuns bool
+		data    []byte
+	)
+
+	runFlags := make([]byte, (n+7)/8)
+
+	for i, c := range b.containers {
+		if _, ok := c.(*runContainer); ok {
+			runFlags[i/8] |= 1 << (i % 8)
+			hasRuns = true
+		}
+	}
+
+	if hasRuns {
+		data = binary.LittleEndian.AppendUint32(data, serialCookie|uint32(n-1)<<16)
+		data = append(data, runFlags...)
+	} else {
+		data = binary.LittleEndian.AppendUint32(data, serialCookieNoRuns)
+		data = binary.LittleEndian.AppendUint32(data, uint32(n))
+	}
+
+	for i, c := range b.containers {
+		data = binary.LittleEndian.AppendUint16(data, b.keys[i])
+		data = binary.LittleEndian.AppendUint16(data, uint16(c.cardinality()-1))
+	}
+
+	if !hasRuns || n >= noOffsetThreshold {
+		// Offsets of each container's data from the start, which begins right after the offsets themselves
+		offset := len(data) + 4*n
+
+		for _, c := range b.containers {
+			data = binary.LittleEndian.AppendUint32(data, uint32(offset))
+			offset += serializedSize(c)
+		}
+	}
+
+	for _, c := range b.containers {
+		data = appendContainer(data, c)
+	}
+
+	return data, nil
+}
+
+// UnmarshalBinary replaces the contents of the bitmap with the ones encoded in the portable Roaring format.
+func (b *Bitmap) UnmarshalBinary(data []byte) error {
+	var (
+		d        = &decoder{data: data}
+		n        int
+		runFlags []byte
+	)
+
+	switch cookie := d.uint32(); {
+	case cookie&0xFFFF == serialCookie:
+		n = int(cookie>>16) + 1
+		runFlags = d.next((n + 7) / 8)
+	case cookie == serialCookieNoRuns:
+		n = int(d.uint32())
+	default:
+		return ErrInvalidData
+	}
+
+	// Every container needs at least 4 bytes of header, which guards the allocations below
+	if n > 1<<16 || n*4 > len(data) {
+		return ErrInvalidData
+	}
+
+	var (
+		keys  = make([]uint16, n)
+		cards = make([]int, n)
+	)
+
+	for i := range keys {
+		keys[i] = d.uint16()
+		cards[i] = int(d.uint16()) + 1
+
+		if i > 0 && keys[i] <= keys[i-1] {
+			return ErrInvalidData
+		}
+	}
+
+	if runFlags == nil || n >= noOffsetThreshold {
+		// Containers are laid out one after another, so their offsets are not needed
+		d.next(4 * n)
+	}
+
+	containers := make([]container, n)
+
+	for i := range containers {
+		isRun := runFlags != nil && runFlags[i/8]&(1<<(i%8)) != 0
+
+		c, ok := d.container(isRun, cards[i])
+		if !ok || d.failed {
+			return ErrInvalidData
+		}
+
+		containers[i] = c
+	}
+
+	if d.failed {
+		return ErrInvalidData
+	}
+
+	b.keys = keys
+	b.containers = containers
+
+	return nil
+}
+
+// serializedSize returns the number of bytes appendContainer writes for the container.
+func serializedSize(c container) int {
+	if run, ok := c.(*runContainer); ok {
+		return 2 + 4*len(run.runs)
+	}
+
+	if card := c.cardinality(); card <= arrayMaxSize {
+		return 2 * card
+	}
+
+	return bitmapWords * 8
+}
+
+func appendContainer(data []byte, c container) []byte {
+	if run, ok := c.(*runContainer); ok {
+		data = binary.LittleEndian.AppendUint16(data, uint16(len(run.runs)))
+
+		for _, r := range run.runs {
+			data = binary.LittleEndian.AppendUint16(data, r.start)
+			data = binary.LittleEndian.AppendUint16(data, r.end-r.start)
+		}
+
+		return data
+	}
+
+	// The kind of non-run containers is implied by their cardinality
+	if c.cardinality() <= arrayMaxSize {
+		for _, x := range toArray(c).values {
+			data = binary.LittleEndian.AppendUint16(data, x)
+		}
+
+		return data
+	}
+
+	for _, word := range toBitmap(c).words {
+		data = binary.LittleEndian.AppendUint64(data, word)
+	}
+
+	return data
+}
+
+// decoder reads little-endian values, remembering whether it ever ran out of data.
+type decoder struct {
+	data   []byte
+	pos    int
+	failed bool
+}
+
+func (d *decoder) next(n int) []byte {
+	if d.failed || n > len(d.data)-d.pos {
+		d.failed = true
+		return make([]byte, n)
+	}
+
+	d.pos += n
+
+	return d.data[d.pos-n : d.pos]
+}
+
+func (d *decoder) uint16() uint16 {
+	return binary.LittleEndian.Uint16(d.next(2))
+}
+
+func (d *decoder) uint32() uint32 {
+	return binary.LittleEndian.Uint32(d.next(4))
+}
+
+func (d *decoder) uint64() uint64 {
+	return binary.LittleEndian.Uint64(d.next(8))
+}
+
+// container reads a container, returning false if it does not hold 'card' sorted values.
+func (d *decoder) container(isRun bool, card int) (container, bool) {
+	if isRun {
+		run := &runContainer{runs: make([]interval, 0, d.uint16())}
+
+		for i := 0; i < cap(run.runs) && !d.failed; i++ {
+			start, length := d.uint16(), d.uint16()
+			if int(start)+int(length) > 1<<16-1 {
+				return nil, false
+			}
+
+			r := interval{start: start, end: start + length}
+
+			last := len(run.runs) - 1
+
+			switch {
+			case last >= 0 && r.start <= run.runs[last].end:
+				return nil, false
+			case last >= 0 && r.start == run.runs[last].end+1:
+				// Touching runs are valid, but are kept merged
+				run.runs[last].end = r.end
+			default:
+				run.runs = append(run.runs, r)
+			}
+		}
+
+		return run, run.cardinality() == card
+	}
+
+	if card <= arrayMaxSize {
+		arr := &arrayContainer{values: make([]uint16, card)}
+
+		for i := range arr.values {
+			arr.values[i] = d.uint16()
+
+			if i > 0 && arr.values[i] <= arr.values[i-1] && !d.failed {
+				return nil, false
+			}
+		}
+
+		return arr, true
+	}
+
+	bm := newBitmapContainer()
+	for i := range bm.words {
+		bm.words[i] = d.uint64()
+		bm.card += bits.OnesCount64(bm.words[i])
+	}
+
+	return bm, bm.card == card
+}
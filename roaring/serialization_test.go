@@ -0,0 +1,71 @@
+package roaring_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/igorroncevic/go-utils/roaring"
+)
+
+func TestMarshalBinaryFormat(t *testing.T) {
+	// Cookie and container count, key with cardinality - 1, offset and the array container
+	arrays := roaring.FromSlice([]uint32{1, 2, 3})
+
+	data, err := arrays.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{
+		0x3A, 0x30, 0, 0, 1, 0, 0, 0,
+		0, 0, 2, 0,
+		16, 0, 0, 0,
+		1, 0, 2, 0, 3, 0,
+	}, data)
+
+	// Cookie with container count - 1, run flags, key with cardinality - 1 and the run container, without offsets
+	runs := roaring.New()
+	for x := uint32(1); x <= 100; x++ {
+		runs.Add(x)
+	}
+
+	runs.RunOptimize()
+
+	data, err = runs.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{
+		0x3B, 0x30, 0, 0, 1,
+		0, 0, 99, 0,
+		1, 0, 1, 0, 99, 0,
+	}, data)
+}
+
+func TestMarshalBinaryRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(3)) //nolint:gosec // deterministic test data
+	values := randomValues(r, 20000)
+
+	for _, optimize := range []bool{false, true} {
+		b := roaring.FromSlice(values)
+		if optimize {
+			b.RunOptimize()
+		}
+
+		data, err := b.MarshalBinary()
+		assert.NoError(t, err)
+
+		decoded := roaring.New()
+		assert.NoError(t, decoded.UnmarshalBinary(data))
+		assert.Equal(t, values, decoded.ToSlice())
+
+		// Truncated data is rejected, and leaves the bitmap as it was
+		assert.Equal(t, roaring.ErrInvalidData, decoded.UnmarshalBinary(data[:len(data)-1]))
+		assert.Equal(t, uint64(len(values)), decoded.Cardinality())
+	}
+
+	empty, err := roaring.New().MarshalBinary()
+	assert.NoError(t, err)
+
+	decoded := roaring.FromSlice([]uint32{1})
+	assert.NoError(t, decoded.UnmarshalBinary(empty))
+	assert.True(t, decoded.IsEmpty())
+
+	assert.Equal(t, roaring.ErrInvalidData, decoded.UnmarshalBinary([]byte{1, 2, 3, 4}))
+}
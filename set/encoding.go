@@ -0,0 +1,181 @@
+package set
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+
+	"github.com/igorroncevic/go-utils/hashmap"
+	"github.com/igorroncevic/go-utils/internal/encode"
+)
+
+var (
+	ErrMissingFuncs = fmt.Errorf("set has no equals or hash function, construct it with NewHash before decoding")
+)
+
+// MarshalJSON encodes the set as an array, ordered by the JSON encoding of its values
+// so the same set always yields the same output.
+func (s *Set[T]) MarshalJSON() ([]byte, error) {
+	values, err := sortedValues[T](s, encode.JSON)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(values)
+}
+
+// UnmarshalJSON replaces the contents of the set with the decoded values.
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	var values []T
+
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	s.load(values)
+
+	return nil
+}
+
+// MarshalBinary encodes the set using gob, ordered by the gob encoding of its values.
+func (s *Set[T]) MarshalBinary() ([]byte, error) {
+	values, err := sortedValues[T](s, encode.Gob)
+	if err != nil {
+		return nil, err
+	}
+
+	return encode.Gob(values)
+}
+
+// UnmarshalBinary replaces the contents of the set with the values encoded by MarshalBinary.
+func (s *Set[T]) UnmarshalBinary(data []byte) error {
+	var values []T
+
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&values); err != nil {
+		return err
+	}
+
+	s.load(values)
+
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder.
+func (s *Set[T]) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder.
+func (s *Set[T]) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
+
+// sortedValues returns all of the values of the set ordered by their encoded representation.
+func sortedValues[T any](s iterable[T], encodeFn func(val any) ([]byte, error)) ([]T, error) {
+	values := make([]T, 0, s.Size())
+
+	s.Each(func(val T) {
+		values = append(values, val)
+	})
+
+	if err := encode.SortByEncoding(values, encodeFn, valueParts[T]); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// iterable is implemented by both Set and HashSet.
+type iterable[T any] interface {
+	Each(fn func(val T))
+	Size() int
+}
+
+// load resets the set and fills it with the decoded values.
+func (s *Set[T]) load(values []T) {
+	s.values = make(map[T]bool, len(values))
+
+	for _, val := range values {
+		s.values[val] = true
+	}
+}
+
+// MarshalJSON encodes the set as an array, ordered by the JSON encoding of its values
+// so the same set always yields the same output.
+func (s *HashSet[T]) MarshalJSON() ([]byte, error) {
+	values, err := sortedValues[T](s, encode.JSON)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(values)
+}
+
+// UnmarshalJSON replaces the contents of the set with the decoded values.
+// The set must be constructed with NewHash beforehand, so that it knows how to hash and compare values.
+//
+//	s := set.NewHash(0, bytes.Equal, util.HashBytes)
+//	err := json.Unmarshal(data, s)
+func (s *HashSet[T]) UnmarshalJSON(data []byte) error {
+	var values []T
+
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	return s.load(values)
+}
+
+// MarshalBinary encodes the set using gob, ordered by the gob encoding of its values.
+func (s *HashSet[T]) MarshalBinary() ([]byte, error) {
+	values, err := sortedValues[T](s, encode.Gob)
+	if err != nil {
+		return nil, err
+	}
+
+	return encode.Gob(values)
+}
+
+// UnmarshalBinary replaces the contents of the set with the values encoded by MarshalBinary.
+// Same as with UnmarshalJSON, the set must be constructed with NewHash beforehand.
+func (s *HashSet[T]) UnmarshalBinary(data []byte) error {
+	var values []T
+
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&values); err != nil {
+		return err
+	}
+
+	return s.load(values)
+}
+
+// GobEncode implements gob.GobEncoder.
+func (s *HashSet[T]) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder.
+func (s *HashSet[T]) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
+
+// load resets the set and fills it with the decoded values.
+func (s *HashSet[T]) load(values []T) error {
+	if s.equals == nil || s.hash == nil {
+		return ErrMissingFuncs
+	}
+
+	// Entries may be shared with a copy of the set, so start over instead of clearing them
+	s.values = hashmap.New[T, struct{}](uint64(len(values)), s.equals, s.hash)
+
+	for _, val := range values {
+		s.values.Put(val, struct{}{})
+	}
+
+	return nil
+}
+
+// valueParts sorts values by their own encoding.
+func valueParts[T any](val T) []any {
+	return []any{val}
+}
@@ -0,0 +1,100 @@
+package set_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/igorroncevic/go-utils/set"
+	"github.com/igorroncevic/go-utils/util"
+)
+
+func TestSetJSON(t *testing.T) {
+	s := set.New[string]()
+	assert.NoError(t, s.Add("foo"))
+	assert.NoError(t, s.Add("bar"))
+	assert.NoError(t, s.Add("baz"))
+
+	data, err := json.Marshal(s)
+	assert.NoError(t, err)
+	assert.Equal(t, `["bar","baz","foo"]`, string(data))
+
+	var decoded set.Set[string]
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, s, &decoded)
+}
+
+func TestSetGob(t *testing.T) {
+	s := set.New[int]()
+	for i := 0; i < 50; i++ {
+		assert.NoError(t, s.Add(i))
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, gob.NewEncoder(&buf).Encode(s))
+
+	decoded := set.New[int]()
+	assert.NoError(t, gob.NewDecoder(&buf).Decode(decoded))
+	assert.Equal(t, s, decoded)
+
+	first, err := s.MarshalBinary()
+	assert.NoError(t, err)
+
+	second, err := decoded.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, first, second)
+}
+
+func TestHashSetJSON(t *testing.T) {
+	s := set.NewHash(0, bytes.Equal, util.HashBytes)
+	assert.NoError(t, s.Add([]byte("foo")))
+	assert.NoError(t, s.Add([]byte("bar")))
+
+	// Byte slices encode as base64 strings, "YmFy" being "bar" and "Zm9v" being "foo"
+	data, err := json.Marshal(s)
+	assert.NoError(t, err)
+	assert.Equal(t, `["YmFy","Zm9v"]`, string(data))
+
+	decoded := set.NewHash(0, bytes.Equal, util.HashBytes)
+	assert.NoError(t, decoded.Add([]byte("stale")))
+	assert.NoError(t, json.Unmarshal(data, decoded))
+	assert.Equal(t, 2, decoded.Size())
+	assert.True(t, decoded.Contains([]byte("foo")))
+	assert.False(t, decoded.Contains([]byte("stale")))
+
+	// Decoding needs the equals and hash functions
+	var empty set.HashSet[[]byte]
+	assert.Equal(t, set.ErrMissingFuncs, json.Unmarshal(data, &empty))
+}
+
+func TestHashSetGob(t *testing.T) {
+	s := set.NewHash(0, util.Equals[int], util.HashInt)
+	for i := 0; i < 50; i++ {
+		assert.NoError(t, s.Add(i))
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, gob.NewEncoder(&buf).Encode(s))
+
+	decoded := set.NewHash(0, util.Equals[int], util.HashInt)
+	cpy := decoded.Copy()
+
+	assert.NoError(t, gob.NewDecoder(&buf).Decode(decoded))
+	assert.Equal(t, 50, decoded.Size())
+	assert.Equal(t, 0, cpy.Size(), "copy was affected by decoding")
+
+	// Output is deterministic regardless of capacity and insertion order
+	other := set.NewHash(64, util.Equals[int], util.HashInt)
+	for i := 49; i >= 0; i-- {
+		assert.NoError(t, other.Add(i))
+	}
+
+	first, err := s.MarshalBinary()
+	assert.NoError(t, err)
+
+	second, err := other.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, first, second)
+}
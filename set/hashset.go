@@ -0,0 +1,112 @@
+package set
+
+import (
+	"fmt"
+
+	"github.com/igorroncevic/go-utils/hashmap"
+	"github.com/igorroncevic/go-utils/util"
+)
+
+// HashSet is a set with the same API as Set, for values that are not comparable, e.g. []byte.
+// Values are told apart by the equals and hash funcs, the same as keys of hashmap.Map.
+type HashSet[T any] struct {
+	values *hashmap.Map[T, struct{}]
+
+	equals util.EqualsFn[T]
+	hash   util.HashFn[T]
+}
+
+// NewHash constructs a new, empty set with the given capacity.
+func NewHash[T any](capacity uint64, equals util.EqualsFn[T], hash util.HashFn[T]) *HashSet[T] {
+	return &HashSet[T]{
+		values: hashmap.New[T, struct{}](capacity, equals, hash),
+		equals: equals,
+		hash:   hash,
+	}
+}
+
+func (s *HashSet[T]) Add(val T) error {
+	if s.Contains(val) {
+		return fmt.Errorf("value '%v' already exists", val)
+	}
+
+	s.values.Put(val, struct{}{})
+
+	return nil
+}
+
+func (s *HashSet[T]) Clear() {
+	s.values.Clear()
+}
+
+func (s *HashSet[T]) Each(fn func(val T)) {
+	s.values.Each(func(val T, _ struct{}) {
+		fn(val)
+	})
+}
+
+func (s *HashSet[T]) Contains(val T) bool {
+	_, exists := s.values.Get(val)
+	return exists
+}
+
+func (s *HashSet[T]) Remove(val T) error {
+	if !s.Contains(val) {
+		return fmt.Errorf("value '%v' does not exist", val)
+	}
+
+	s.values.Remove(val)
+
+	return nil
+}
+
+func (s *HashSet[T]) Size() int {
+	return s.values.Size()
+}
+
+// Union returns a new set with the values that are in either of the sets.
+func (s *HashSet[T]) Union(other *HashSet[T]) *HashSet[T] {
+	result := s.Copy()
+
+	other.Each(func(val T) {
+		result.values.Put(val, struct{}{})
+	})
+
+	return result
+}
+
+// Intersect returns a new set with the values that are in both of the sets.
+func (s *HashSet[T]) Intersect(other *HashSet[T]) *HashSet[T] {
+	result := NewHash(uint64(util.Min(s.Size(), other.Size())), s.equals, s.hash)
+
+	s.Each(func(val T) {
+		if other.Contains(val) {
+			result.values.Put(val, struct{}{})
+		}
+	})
+
+	return result
+}
+
+// Difference returns a new set with the values of this set that are not in 'other'.
+func (s *HashSet[T]) Difference(other *HashSet[T]) *HashSet[T] {
+	result := NewHash(uint64(s.Size()), s.equals, s.hash)
+
+	s.Each(func(val T) {
+		if !other.Contains(val) {
+			result.values.Put(val, struct{}{})
+		}
+	})
+
+	return result
+}
+
+// Copy returns a copy of this set. Same as with hashmap.Map, the copy will not allocate
+// any memory until the first write to either of the sets.
+func (s *HashSet[T]) Copy() *HashSet[T] {
+	return &HashSet[T]{
+		values: s.values.Copy(),
+		equals: s.equals,
+		hash:   s.hash,
+	}
+}
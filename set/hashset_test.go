@@ -0,0 +1,70 @@
+package set_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/igorroncevic/go-utils/set"
+	"github.com/igorroncevic/go-utils/util"
+)
+
+func TestHashSet(t *testing.T) {
+	s := set.NewHash(0, bytes.Equal, util.HashBytes)
+
+	assert.NoError(t, s.Add([]byte("foo")))
+	assert.NoError(t, s.Add([]byte("bar")))
+	assert.Error(t, s.Add([]byte("foo")))
+
+	assert.True(t, s.Contains([]byte("foo")))
+	assert.False(t, s.Contains([]byte("baz")))
+	assert.Equal(t, 2, s.Size(), "unexpected set size")
+
+	var visited int
+
+	s.Each(func(val []byte) {
+		assert.True(t, s.Contains(val))
+		visited++
+	})
+	assert.Equal(t, 2, visited)
+
+	assert.NoError(t, s.Remove([]byte("foo")))
+	assert.Error(t, s.Remove([]byte("foo")))
+	assert.Equal(t, 1, s.Size(), "unexpected set size")
+
+	s.Clear()
+	assert.Equal(t, 0, s.Size(), "unexpected set size")
+}
+
+func TestHashSetAlgebra(t *testing.T) {
+	a := set.NewHash(0, util.Equals[int], util.HashInt)
+	b := set.NewHash(0, util.Equals[int], util.HashInt)
+
+	for _, val := range []int{1, 2, 3} {
+		_ = a.Add(val)
+	}
+
+	for _, val := range []int{2, 3, 4} {
+		_ = b.Add(val)
+	}
+
+	assert.Equal(t, []int{1, 2, 3, 4}, sorted(a.Union(b)))
+	assert.Equal(t, []int{2, 3}, sorted(a.Intersect(b)))
+	assert.Equal(t, []int{1}, sorted(a.Difference(b)))
+
+	// Operands are not modified
+	assert.Equal(t, []int{1, 2, 3}, sorted(a))
+	assert.Equal(t, []int{2, 3, 4}, sorted(b))
+}
+
+func TestHashSetCopy(t *testing.T) {
+	s := set.NewHash(0, util.Equals[int], util.HashInt)
+	_ = s.Add(1)
+
+	cpy := s.Copy()
+	_ = cpy.Add(2)
+	_ = s.Remove(1)
+
+	assert.Equal(t, []int{}, sorted(s))
+	assert.Equal(t, []int{1, 2}, sorted(cpy))
+}
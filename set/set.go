@@ -50,3 +50,44 @@ func (s *Set[T]) Remove(val T) error {
 func (s *Set[T]) Size() int {
 	return len(s.values)
 }
+
+// Union returns a new set with the values that are in either of the sets.
+func (s *Set[T]) Union(other *Set[T]) *Set[T] {
+	result := New[T]()
+
+	for val := range s.values {
+		result.values[val] = true
+	}
+
+	for val := range other.values {
+		result.values[val] = true
+	}
+
+	return result
+}
+
+// Intersect returns a new set with the values that are in both of the sets.
+func (s *Set[T]) Intersect(other *Set[T]) *Set[T] {
+	result := New[T]()
+
+	for val := range s.values {
+		if other.Contains(val) {
+			result.values[val] = true
+		}
+	}
+
+	return result
+}
+
+// Difference returns a new set with the values of this set that are not in 'other'.
+func (s *Set[T]) Difference(other *Set[T]) *Set[T] {
+	result := New[T]()
+
+	for val := range s.values {
+		if !other.Contains(val) {
+			result.values[val] = true
+		}
+	}
+
+	return result
+}
@@ -1,6 +1,7 @@
 package set_test
 
 import (
+	"sort"
 	"testing"
 
 	"github.com/alecthomas/assert"
@@ -52,3 +53,35 @@ func TestSet(t *testing.T) {
 	s.Clear()
 	assert.Equal(t, 0, s.Size(), "unexpected set size")
 }
+
+func TestSetAlgebra(t *testing.T) {
+	a, b := set.New[int](), set.New[int]()
+
+	for _, val := range []int{1, 2, 3} {
+		_ = a.Add(val)
+	}
+
+	for _, val := range []int{2, 3, 4} {
+		_ = b.Add(val)
+	}
+
+	assert.Equal(t, []int{1, 2, 3, 4}, sorted(a.Union(b)))
+	assert.Equal(t, []int{2, 3}, sorted(a.Intersect(b)))
+	assert.Equal(t, []int{1}, sorted(a.Difference(b)))
+	assert.Equal(t, []int{4}, sorted(b.Difference(a)))
+
+	// Operands are not modified
+	assert.Equal(t, []int{1, 2, 3}, sorted(a))
+}
+
+func sorted(s interface{ Each(fn func(val int)) }) []int {
+	values := []int{}
+
+	s.Each(func(val int) {
+		values = append(values, val)
+	})
+
+	sort.Ints(values)
+
+	return values
+}
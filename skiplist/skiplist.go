@@ -0,0 +1,290 @@
+// Package skiplist implements an indexable skip list, an ordered container with
+// O(log n) expected insertion, search, deletion and rank queries.
+package skiplist
+
+import (
+	"math"
+
+	"github.com/igorroncevic/go-utils/util"
+)
+
+const (
+	maxLevel = 32
+
+	// Each level holds roughly a quarter of the nodes of the level below it.
+	levelBits = 2
+)
+
+// Source is a source of random numbers used to pick node levels. It is satisfied by math/rand.Source,
+// so a seeded source can be injected to get deterministic levels, e.g. in tests.
+type Source interface {
+	Int63() int64
+}
+
+type SkipList[K, V any] struct {
+	head   *node[K, V]
+	level  int
+	length int
+
+	less   util.LessFn[K]
+	source Source
+}
+
+type node[K, V any] struct {
+	key   K
+	value V
+	next  []link[K, V]
+}
+
+// link points to the next node on a level. Its span is the number of nodes it skips over,
+// counting the one it points to, which is what makes rank queries possible.
+type link[K, V any] struct {
+	node *node[K, V]
+	span int
+}
+
+// New constructs a new skip list ordered by the less func.
+func New[K, V any](less util.LessFn[K]) *SkipList[K, V] {
+	seed, err := util.RandomInt64(math.MaxInt64)
+	if err != nil {
+		seed = 1
+	}
+
+	return NewWithSource[K, V](less, &xorshift{state: uint64(seed) | 1})
+}
+
+// NewWithSource constructs a new skip list ordered by the less func, which uses 'source' to pick node levels.
+func NewWithSource[K, V any](less util.LessFn[K], source Source) *SkipList[K, V] {
+	return &SkipList[K, V]{
+		head:   &node[K, V]{next: make([]link[K, V], maxLevel)},
+		level:  1,
+		less:   less,
+		source: source,
+	}
+}
+
+// Insert maps the given key to the given value. If the key already exists its
+// value will be overwritten with the new value.
+func (s *SkipList[K, V]) Insert(key K, val V) {
+	var (
+		update [maxLevel]*node[K, V]
+		rank   [maxLevel]int
+		curr   = s.head
+	)
+
+	// Find the last node before 'key' on every level, along with its rank
+	for i := s.level - 1; i >= 0; i-- {
+		if i < s.level-1 {
+			rank[i] = rank[i+1]
+		}
+
+		for curr.next[i].node != nil && s.less(curr.next[i].node.key, key) {
+			rank[i] += curr.next[i].span
+			curr = curr.next[i].node
+		}
+
+		update[i] = curr
+	}
+
+	if next := curr.next[0].node; next != nil && !s.less(key, next.key) {
+		next.value = val
+		return
+	}
+
+	level := s.randomLevel()
+	if level > s.level {
+		for i := s.level; i < level; i++ {
+			update[i] = s.head
+			update[i].next[i].span = s.length
+		}
+
+		s.level = level
+	}
+
+	newNode := &node[K, V]{key: key, value: val, next: make([]link[K, V], level)}
+
+	for i := 0; i < level; i++ {
+		newNode.next[i].node = update[i].next[i].node
+		update[i].next[i].node = newNode
+
+		// rank[0] - rank[i] is the distance between update[i] and the node right before the new one
+		newNode.next[i].span = update[i].next[i].span - (rank[0] - rank[i])
+		update[i].next[i].span = rank[0] - rank[i] + 1
+	}
+
+	// Links above the new node now skip over one more node
+	for i := level; i < s.level; i++ {
+		update[i].next[i].span++
+	}
+
+	s.length++
+}
+
+// Search returns the value stored for this key, or false if there is no such value.
+func (s *SkipList[K, V]) Search(key K) (V, bool) {
+	if n := s.seek(key); n != nil && !s.less(key, n.key) {
+		return n.value, true
+	}
+
+	var empty V
+
+	return empty, false
+}
+
+// Delete removes the key from the skip list and returns whether it was present.
+func (s *SkipList[K, V]) Delete(key K) bool {
+	var (
+		update [maxLevel]*node[K, V]
+		curr   = s.head
+	)
+
+	for i := s.level - 1; i >= 0; i-- {
+		for curr.next[i].node != nil && s.less(curr.next[i].node.key, key) {
+			curr = curr.next[i].node
+		}
+
+		update[i] = curr
+	}
+
+	target := curr.next[0].node
+	if target == nil || s.less(key, target.key) {
+		return false
+	}
+
+	for i := 0; i < s.level; i++ {
+		if update[i].next[i].node == target {
+			update[i].next[i].span += target.next[i].span - 1
+			update[i].next[i].node = target.next[i].node
+		} else {
+			update[i].next[i].span--
+		}
+	}
+
+	for s.level > 1 && s.head.next[s.level-1].node == nil {
+		s.level--
+	}
+
+	s.length--
+
+	return true
+}
+
+// IndexOf returns the 0-based rank of the key, or false if the key is not present.
+func (s *SkipList[K, V]) IndexOf(key K) (int, bool) {
+	var (
+		rank int
+		curr = s.head
+	)
+
+	for i := s.level - 1; i >= 0; i-- {
+		// next.key <= key
+		for curr.next[i].node != nil && !s.less(key, curr.next[i].node.key) {
+			rank += curr.next[i].span
+			curr = curr.next[i].node
+		}
+
+		if curr != s.head && !s.less(curr.key, key) {
+			return rank - 1, true
+		}
+	}
+
+	return -1, false
+}
+
+// At returns the key-value pair with the given 0-based rank, or false if the rank is out of range.
+func (s *SkipList[K, V]) At(rank int) (K, V, bool) {
+	var (
+		traversed int
+		target    = rank + 1
+		curr      = s.head
+	)
+
+	if rank >= 0 && rank < s.length {
+		for i := s.level - 1; i >= 0; i-- {
+			for curr.next[i].node != nil && traversed+curr.next[i].span <= target {
+				traversed += curr.next[i].span
+				curr = curr.next[i].node
+			}
+
+			if traversed == target {
+				return curr.key, curr.value, true
+			}
+		}
+	}
+
+	var (
+		emptyKey K
+		emptyVal V
+	)
+
+	return emptyKey, emptyVal, false
+}
+
+// Range calls 'fn' on every key-value pair where from <= key < to, in ascending order.
+// Iteration stops as soon as 'fn' returns true.
+func (s *SkipList[K, V]) Range(from, to K, fn func(key K, val V) bool) {
+	for n := s.seek(from); n != nil && s.less(n.key, to); n = n.next[0].node {
+		if shouldStop := fn(n.key, n.value); shouldStop {
+			return
+		}
+	}
+}
+
+// Each calls 'fn' on every key-value pair in ascending order.
+// Iteration stops as soon as 'fn' returns true.
+func (s *SkipList[K, V]) Each(fn func(key K, val V) bool) {
+	for n := s.head.next[0].node; n != nil; n = n.next[0].node {
+		if shouldStop := fn(n.key, n.value); shouldStop {
+			return
+		}
+	}
+}
+
+// Size returns the number of items in the skip list.
+func (s *SkipList[K, V]) Size() int {
+	return s.length
+}
+
+// Clear removes all key-value pairs from the skip list.
+func (s *SkipList[K, V]) Clear() {
+	s.head = &node[K, V]{next: make([]link[K, V], maxLevel)}
+	s.level = 1
+	s.length = 0
+}
+
+// seek returns the first node where `node.key >= key`, or nil if there is none.
+func (s *SkipList[K, V]) seek(key K) *node[K, V] {
+	curr := s.head
+
+	for i := s.level - 1; i >= 0; i-- {
+		for curr.next[i].node != nil && s.less(curr.next[i].node.key, key) {
+			curr = curr.next[i].node
+		}
+	}
+
+	return curr.next[0].node
+}
+
+// randomLevel picks a level for a new node, where each level is 4 times less likely than the previous one.
+func (s *SkipList[K, V]) randomLevel() int {
+	level := 1
+
+	for level < maxLevel && s.source.Int63()&(1<<levelBits-1) == 0 {
+		level++
+	}
+
+	return level
+}
+
+// xorshift is a small and fast pseudo-random source used when none is injected.
+type xorshift struct {
+	state uint64
+}
+
+func (x *xorshift) Int63() int64 {
+	x.state ^= x.state << 13
+	x.state ^= x.state >> 7
+	x.state ^= x.state << 17
+
+	return int64(x.state >> 1)
+}
@@ -0,0 +1,106 @@
+package skiplist_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/igorroncevic/go-utils/skiplist"
+	"github.com/igorroncevic/go-utils/util"
+)
+
+// Check if the skip list holds exactly the keys from the sorted slice, with matching ranks.
+func checkeq(s *skiplist.SkipList[int, int], keys []int, t *testing.T) {
+	assert.Equal(t, len(keys), s.Size(), "unexpected skip list size")
+
+	visited := []int{}
+
+	s.Each(func(key, val int) bool {
+		visited = append(visited, key)
+		return false
+	})
+
+	assert.Equal(t, keys, visited, "unexpected iteration order")
+
+	for i, key := range keys {
+		rank, ok := s.IndexOf(key)
+		assert.True(t, ok, "key %d not found", key)
+		assert.Equal(t, i, rank, "unexpected rank of key %d", key)
+
+		atKey, atVal, ok := s.At(i)
+		assert.True(t, ok, "rank %d not found", i)
+		assert.Equal(t, key, atKey, "unexpected key at rank %d", i)
+		assert.Equal(t, key*10, atVal, "unexpected value at rank %d", i)
+	}
+}
+
+func TestSkipListVsSortedSliceCrossCheck(t *testing.T) {
+	source := rand.NewSource(42)
+	random := rand.New(source) //nolint:gosec // deterministic test data
+	s := skiplist.NewWithSource[int, int](util.Less[int], source)
+	stdmap := make(map[int]bool)
+
+	for i := 0; i < 2000; i++ {
+		key := random.Intn(500)
+
+		if random.Intn(3) == 0 {
+			assert.Equal(t, stdmap[key], s.Delete(key), "unexpected delete result for %d", key)
+			delete(stdmap, key)
+		} else {
+			s.Insert(key, key*10)
+			stdmap[key] = true
+		}
+	}
+
+	keys := make([]int, 0, len(stdmap))
+	for key := range stdmap {
+		keys = append(keys, key)
+	}
+
+	sort.Ints(keys)
+
+	checkeq(s, keys, t)
+}
+
+func TestSkipList(t *testing.T) {
+	s := skiplist.New[int, int](util.Less[int])
+
+	for _, key := range []int{5, 1, 9, 3, 7} {
+		s.Insert(key, key*10)
+	}
+
+	checkeq(s, []int{1, 3, 5, 7, 9}, t)
+
+	// Overwrite existing key
+	s.Insert(5, 42)
+	val, ok := s.Search(5)
+	assert.True(t, ok)
+	assert.Equal(t, 42, val)
+	assert.Equal(t, 5, s.Size(), "unexpected size after overwrite")
+
+	_, ok = s.Search(4)
+	assert.False(t, ok)
+
+	_, ok = s.IndexOf(4)
+	assert.False(t, ok)
+
+	_, _, ok = s.At(5)
+	assert.False(t, ok)
+
+	// Range is inclusive on the lower and exclusive on the upper bound
+	ranged := []int{}
+
+	s.Range(2, 7, func(key, val int) bool {
+		ranged = append(ranged, key)
+		return false
+	})
+
+	assert.Equal(t, []int{3, 5}, ranged)
+
+	s.Clear()
+	assert.Equal(t, 0, s.Size(), "unexpected size after clear")
+
+	_, ok = s.Search(1)
+	assert.False(t, ok)
+}
@@ -0,0 +1,207 @@
+package stack
+
+import (
+	"fmt"
+
+	"golang.org/x/exp/constraints"
+
+	"github.com/igorroncevic/go-utils/util"
+)
+
+// Monoid is an associative operation with an identity element, e.g. addition with 0.
+type Monoid[T any] interface {
+	// Identity returns the element that leaves any other element unchanged when combined with it.
+	Identity() T
+	// Combine returns the result of the operation. It must be associative, but does not have to be commutative.
+	Combine(a, b T) T
+}
+
+// AggregateStack is a stack that keeps the aggregate of all of its elements, combined from
+// the bottom to the top, which is available in O(1) at all times.
+type AggregateStack[T any] struct {
+	elems      *Stack[T]
+	aggregates *Stack[T]
+
+	monoid Monoid[T]
+}
+
+// MinStack is a stack that reports its smallest element in O(1).
+type MinStack[T any] struct {
+	*AggregateStack[T]
+}
+
+// MaxStack is a stack that reports its largest element in O(1).
+type MaxStack[T any] struct {
+	*AggregateStack[T]
+}
+
+func NewAggregate[T any](monoid Monoid[T]) *AggregateStack[T] {
+	return &AggregateStack[T]{
+		elems:      New[T](),
+		aggregates: New[T](),
+		monoid:     monoid,
+	}
+}
+
+func NewMin[T any](less util.LessFn[T]) *MinStack[T] {
+	return &MinStack[T]{NewAggregate[T](minMonoid[T]{less})}
+}
+
+func NewMax[T any](less util.LessFn[T]) *MaxStack[T] {
+	return &MaxStack[T]{NewAggregate[T](maxMonoid[T]{less})}
+}
+
+// IsEmpty: check if stack is empty
+func (s *AggregateStack[T]) IsEmpty() bool {
+	return s.elems.IsEmpty()
+}
+
+// Push a new value onto the stack
+func (s *AggregateStack[T]) Push(val T) {
+	aggregate := val
+
+	// Bottom element is its own aggregate, so identity never has to be combined with anything
+	if top, err := s.aggregates.Peek(); err == nil {
+		aggregate = s.monoid.Combine(*top, val)
+	}
+
+	s.elems.Push(val)
+	s.aggregates.Push(aggregate)
+}
+
+// Remove and return top element of stack.
+func (s *AggregateStack[T]) Pop() (*T, error) {
+	if _, err := s.aggregates.Pop(); err != nil {
+		return nil, err
+	}
+
+	return s.elems.Pop()
+}
+
+// Peek returns the stack's top element but does not remove it.
+func (s *AggregateStack[T]) Peek() (*T, error) {
+	return s.elems.Peek()
+}
+
+// Aggregate returns all of the elements combined, or the identity if the stack is empty.
+func (s *AggregateStack[T]) Aggregate() T {
+	top, err := s.aggregates.Peek()
+	if err != nil {
+		return s.monoid.Identity()
+	}
+
+	return *top
+}
+
+func (s *AggregateStack[T]) String() string {
+	return s.elems.String()
+}
+
+func (s *AggregateStack[T]) Size() int {
+	return s.elems.Size()
+}
+
+// Copy returns a copy of this stack.
+func (s *AggregateStack[T]) Copy() *AggregateStack[T] {
+	return &AggregateStack[T]{
+		elems:      s.elems.Copy(),
+		aggregates: s.aggregates.Copy(),
+		monoid:     s.monoid,
+	}
+}
+
+// Min returns the smallest element of the stack.
+func (s *MinStack[T]) Min() (*T, error) {
+	return s.nonEmptyAggregate()
+}
+
+// Copy returns a copy of this stack.
+func (s *MinStack[T]) Copy() *MinStack[T] {
+	return &MinStack[T]{s.AggregateStack.Copy()}
+}
+
+// Max returns the largest element of the stack.
+func (s *MaxStack[T]) Max() (*T, error) {
+	return s.nonEmptyAggregate()
+}
+
+// Copy returns a copy of this stack.
+func (s *MaxStack[T]) Copy() *MaxStack[T] {
+	return &MaxStack[T]{s.AggregateStack.Copy()}
+}
+
+func (s *AggregateStack[T]) nonEmptyAggregate() (*T, error) {
+	if s.IsEmpty() {
+		return nil, fmt.Errorf("stack is empty")
+	}
+
+	aggregate := s.Aggregate()
+
+	return &aggregate, nil
+}
+
+// Sum returns a monoid that adds numbers together.
+func Sum[T constraints.Integer | constraints.Float]() Monoid[T] {
+	return sumMonoid[T]{}
+}
+
+// GCD returns a monoid that calculates the greatest common divisor of integers.
+func GCD[T constraints.Integer]() Monoid[T] {
+	return gcdMonoid[T]{}
+}
+
+type sumMonoid[T constraints.Integer | constraints.Float] struct{}
+
+func (sumMonoid[T]) Identity() T {
+	return 0
+}
+
+func (sumMonoid[T]) Combine(a, b T) T {
+	return a + b
+}
+
+type gcdMonoid[T constraints.Integer] struct{}
+
+func (gcdMonoid[T]) Identity() T {
+	return 0
+}
+
+func (gcdMonoid[T]) Combine(a, b T) T {
+	for b != 0 {
+		a, b = b, a%b
+	}
+
+	if a < 0 {
+		return -a
+	}
+
+	return a
+}
+
+// minMonoid has no real identity for an arbitrary type, which is fine, since the stack
+// never combines it and Min checks for emptiness before reading the aggregate.
+type minMonoid[T any] struct {
+	less util.LessFn[T]
+}
+
+func (m minMonoid[T]) Identity() T {
+	var empty T
+	return empty
+}
+
+func (m minMonoid[T]) Combine(a, b T) T {
+	return util.MinFunc(a, b, m.less)
+}
+
+type maxMonoid[T any] struct {
+	less util.LessFn[T]
+}
+
+func (m maxMonoid[T]) Identity() T {
+	var empty T
+	return empty
+}
+
+func (m maxMonoid[T]) Combine(a, b T) T {
+	return util.MaxFunc(a, b, m.less)
+}
@@ -0,0 +1,86 @@
+package stack_test
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/igorroncevic/go-utils/stack"
+	"github.com/igorroncevic/go-utils/util"
+)
+
+func TestMinMaxStack(t *testing.T) {
+	minStack := stack.NewMin[int](util.Less[int])
+	maxStack := stack.NewMax[int](util.Less[int])
+
+	_, err := minStack.Min()
+	assert.Error(t, err)
+
+	_, err = maxStack.Max()
+	assert.Error(t, err)
+
+	values := []int{5, 7, 3, 8, 3, 1}
+	expectedMins := []int{5, 5, 3, 3, 3, 1}
+	expectedMaxs := []int{5, 7, 7, 8, 8, 8}
+
+	for i, val := range values {
+		minStack.Push(val)
+		maxStack.Push(val)
+
+		min, err := minStack.Min()
+		assert.NoError(t, err)
+		assert.Equal(t, expectedMins[i], *min)
+
+		max, err := maxStack.Max()
+		assert.NoError(t, err)
+		assert.Equal(t, expectedMaxs[i], *max)
+	}
+
+	cpy := minStack.Copy()
+
+	// Aggregates follow the pops back down
+	for i := len(values) - 1; i > 0; i-- {
+		top, err := minStack.Pop()
+		assert.NoError(t, err)
+		assert.Equal(t, values[i], *top)
+
+		_, err = maxStack.Pop()
+		assert.NoError(t, err)
+
+		min, _ := minStack.Min()
+		assert.Equal(t, expectedMins[i-1], *min)
+
+		max, _ := maxStack.Max()
+		assert.Equal(t, expectedMaxs[i-1], *max)
+	}
+
+	min, _ := cpy.Min()
+	assert.Equal(t, 1, *min, "copy was affected by pops")
+	assert.Equal(t, len(values), cpy.Size())
+}
+
+func TestAggregateStack(t *testing.T) {
+	sum := stack.NewAggregate[int](stack.Sum[int]())
+	gcd := stack.NewAggregate[int](stack.GCD[int]())
+
+	assert.Equal(t, 0, sum.Aggregate())
+	assert.Equal(t, 0, gcd.Aggregate())
+
+	for _, val := range []int{12, 18, 30} {
+		sum.Push(val)
+		gcd.Push(val)
+	}
+
+	assert.Equal(t, 60, sum.Aggregate())
+	assert.Equal(t, 6, gcd.Aggregate())
+
+	gcd.Push(-4)
+	assert.Equal(t, 2, gcd.Aggregate())
+
+	_, err := gcd.Pop()
+	assert.NoError(t, err)
+	assert.Equal(t, 6, gcd.Aggregate())
+
+	top, err := sum.Peek()
+	assert.NoError(t, err)
+	assert.Equal(t, 30, *top)
+}
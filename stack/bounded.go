@@ -0,0 +1,150 @@
+package stack
+
+import (
+	"fmt"
+	"sync"
+)
+
+var (
+	ErrFull = fmt.Errorf("stack is full")
+)
+
+// OverflowPolicy determines what happens when a value is pushed onto a full BoundedStack.
+type OverflowPolicy int
+
+const (
+	// Reject makes Push return ErrFull.
+	Reject OverflowPolicy = iota
+	// DropOldest removes the bottom element to make room for the new one.
+	DropOldest
+	// DropNewest discards the pushed value.
+	DropNewest
+	// Block makes Push wait until another goroutine pops an element.
+	Block
+)
+
+// BoundedStack is a stack with a fixed capacity, which is safe for concurrent use.
+// Elements are kept in a ring buffer, so dropping the oldest one is O(1) as well.
+type BoundedStack[T any] struct {
+	mu      sync.Mutex
+	notFull *sync.Cond
+
+	elems   []T
+	bottom  int
+	length  int
+	dropped uint64
+
+	policy OverflowPolicy
+}
+
+func NewBounded[T any](capacity int, policy OverflowPolicy) *BoundedStack[T] {
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	s := &BoundedStack[T]{
+		elems:  make([]T, capacity),
+		policy: policy,
+	}
+	s.notFull = sync.NewCond(&s.mu)
+
+	return s
+}
+
+// IsEmpty: check if stack is empty
+func (s *BoundedStack[T]) IsEmpty() bool {
+	return s.Size() == 0
+}
+
+// Push a new value onto the stack, handling a full stack according to its policy.
+func (s *BoundedStack[T]) Push(val T) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.length == len(s.elems) {
+		switch s.policy {
+		case Reject:
+			s.dropped++
+			return ErrFull
+		case DropNewest:
+			s.dropped++
+			return nil
+		case DropOldest:
+			var empty T
+
+			s.elems[s.bottom] = empty
+			s.bottom = (s.bottom + 1) % len(s.elems)
+			s.length--
+			s.dropped++
+		case Block:
+			for s.length == len(s.elems) {
+				s.notFull.Wait()
+			}
+		}
+	}
+
+	s.elems[s.index(s.length)] = val
+	s.length++
+
+	return nil
+}
+
+// Remove and return top element of stack.
+func (s *BoundedStack[T]) Pop() (*T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.length == 0 {
+		return nil, fmt.Errorf("stack is empty")
+	}
+
+	var empty T
+
+	idx := s.index(s.length - 1)
+	element := s.elems[idx]
+	s.elems[idx] = empty
+	s.length--
+
+	s.notFull.Signal()
+
+	return &element, nil
+}
+
+// Peek returns a copy of the stack's top element but does not remove it.
+func (s *BoundedStack[T]) Peek() (*T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.length == 0 {
+		return nil, fmt.Errorf("stack is empty")
+	}
+
+	element := s.elems[s.index(s.length-1)]
+
+	return &element, nil
+}
+
+func (s *BoundedStack[T]) Size() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.length
+}
+
+// Cap returns the maximum number of elements the stack can hold.
+func (s *BoundedStack[T]) Cap() int {
+	return len(s.elems)
+}
+
+// Dropped returns the number of values that were rejected, discarded or evicted because the stack was full.
+func (s *BoundedStack[T]) Dropped() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.dropped
+}
+
+// index maps the position counted from the bottom of the stack to an index in the ring buffer.
+func (s *BoundedStack[T]) index(pos int) int {
+	return (s.bottom + pos) % len(s.elems)
+}
@@ -0,0 +1,81 @@
+package stack_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert"
+	"github.com/igorroncevic/go-utils/stack"
+)
+
+func fillBounded(t *testing.T, policy stack.OverflowPolicy) *stack.BoundedStack[int] {
+	st := stack.NewBounded[int](3, policy)
+
+	for i := 1; i <= 3; i++ {
+		assert.NoError(t, st.Push(i))
+	}
+
+	return st
+}
+
+func popAll(t *testing.T, st *stack.BoundedStack[int]) []int {
+	popped := []int{}
+
+	for !st.IsEmpty() {
+		val, err := st.Pop()
+		assert.NoError(t, err)
+
+		popped = append(popped, *val)
+	}
+
+	return popped
+}
+
+func TestBoundedStackPolicies(t *testing.T) {
+	rejecting := fillBounded(t, stack.Reject)
+	assert.Equal(t, stack.ErrFull, rejecting.Push(4))
+	assert.Equal(t, uint64(1), rejecting.Dropped())
+	assert.Equal(t, []int{3, 2, 1}, popAll(t, rejecting))
+
+	droppingOldest := fillBounded(t, stack.DropOldest)
+	assert.NoError(t, droppingOldest.Push(4))
+	assert.NoError(t, droppingOldest.Push(5))
+	assert.Equal(t, uint64(2), droppingOldest.Dropped())
+	assert.Equal(t, 3, droppingOldest.Size())
+	assert.Equal(t, []int{5, 4, 3}, popAll(t, droppingOldest))
+
+	droppingNewest := fillBounded(t, stack.DropNewest)
+	assert.NoError(t, droppingNewest.Push(4))
+	assert.Equal(t, uint64(1), droppingNewest.Dropped())
+	assert.Equal(t, []int{3, 2, 1}, popAll(t, droppingNewest))
+
+	_, err := droppingNewest.Pop()
+	assert.Error(t, err)
+}
+
+func TestBoundedStackBlock(t *testing.T) {
+	st := fillBounded(t, stack.Block)
+	pushed := make(chan struct{})
+
+	go func() {
+		assert.NoError(t, st.Push(4))
+		close(pushed)
+	}()
+
+	select {
+	case <-pushed:
+		t.Fatal("push did not block on a full stack")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	top, err := st.Pop()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, *top)
+
+	<-pushed
+
+	top, err = st.Peek()
+	assert.NoError(t, err)
+	assert.Equal(t, 4, *top)
+	assert.Equal(t, uint64(0), st.Dropped())
+}
@@ -0,0 +1,65 @@
+package stack
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+
+	"github.com/igorroncevic/go-utils/internal/encode"
+)
+
+// MarshalJSON encodes the stack as an array, starting with the bottom element.
+func (s *Stack[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.elems)
+}
+
+// UnmarshalJSON replaces the contents of the stack with the decoded elements,
+// where the last element ends up on top of the stack.
+func (s *Stack[T]) UnmarshalJSON(data []byte) error {
+	var elems []T
+
+	if err := json.Unmarshal(data, &elems); err != nil {
+		return err
+	}
+
+	s.load(elems)
+
+	return nil
+}
+
+// MarshalBinary encodes the stack elements using gob, starting with the bottom element.
+func (s *Stack[T]) MarshalBinary() ([]byte, error) {
+	return encode.Gob(s.elems)
+}
+
+// UnmarshalBinary replaces the contents of the stack with the elements encoded by MarshalBinary.
+func (s *Stack[T]) UnmarshalBinary(data []byte) error {
+	var elems []T
+
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&elems); err != nil {
+		return err
+	}
+
+	s.load(elems)
+
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder.
+func (s *Stack[T]) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder.
+func (s *Stack[T]) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
+
+// load resets the stack and fills it with elements ordered from bottom to top.
+func (s *Stack[T]) load(elems []T) {
+	if elems == nil {
+		elems = []T{}
+	}
+
+	s.elems = elems
+}
@@ -0,0 +1,43 @@
+package stack_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/igorroncevic/go-utils/stack"
+)
+
+func TestStackJSON(t *testing.T) {
+	st := stack.New[int]()
+	st.Push(1)
+	st.Push(2)
+	st.Push(3)
+
+	data, err := json.Marshal(st)
+	assert.NoError(t, err)
+	assert.Equal(t, `[1,2,3]`, string(data))
+
+	decoded := stack.New[int]()
+	assert.NoError(t, json.Unmarshal(data, decoded))
+
+	top, err := decoded.Pop()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, *top)
+	assert.Equal(t, 2, decoded.Size())
+}
+
+func TestStackGob(t *testing.T) {
+	st := stack.New[string]()
+	st.Push("foo")
+	st.Push("bar")
+
+	var buf bytes.Buffer
+	assert.NoError(t, gob.NewEncoder(&buf).Encode(st))
+
+	decoded := stack.New[string]()
+	assert.NoError(t, gob.NewDecoder(&buf).Decode(decoded))
+	assert.Equal(t, st, decoded)
+}
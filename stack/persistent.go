@@ -0,0 +1,66 @@
+package stack
+
+import "fmt"
+
+// PersistentStack is an immutable stack, where Push and Pop return a new version in O(1)
+// that shares all of its elements with the version it was made from.
+type PersistentStack[T any] struct {
+	top  *cons[T]
+	size int
+}
+
+// cons is a node of an immutable singly linked-list.
+type cons[T any] struct {
+	value T
+	next  *cons[T]
+}
+
+func NewPersistent[T any]() *PersistentStack[T] {
+	return &PersistentStack[T]{}
+}
+
+// IsEmpty: check if stack is empty
+func (s *PersistentStack[T]) IsEmpty() bool {
+	return s.size == 0
+}
+
+// Push returns a new version of the stack with the value on top.
+func (s *PersistentStack[T]) Push(val T) *PersistentStack[T] {
+	return &PersistentStack[T]{
+		top:  &cons[T]{value: val, next: s.top},
+		size: s.size + 1,
+	}
+}
+
+// Pop returns the top element and a new version of the stack without it.
+func (s *PersistentStack[T]) Pop() (*T, *PersistentStack[T], error) {
+	if s.IsEmpty() {
+		return nil, s, fmt.Errorf("stack is empty")
+	}
+
+	element := s.top.value
+
+	return &element, &PersistentStack[T]{top: s.top.next, size: s.size - 1}, nil
+}
+
+// Peek returns the stack's top element.
+func (s *PersistentStack[T]) Peek() (*T, error) {
+	if s.IsEmpty() {
+		return nil, fmt.Errorf("stack is empty")
+	}
+
+	element := s.top.value
+
+	return &element, nil
+}
+
+func (s *PersistentStack[T]) Size() int {
+	return s.size
+}
+
+// Each calls 'fn' on every element, starting from the top of the stack.
+func (s *PersistentStack[T]) Each(fn func(val T)) {
+	for node := s.top; node != nil; node = node.next {
+		fn(node.value)
+	}
+}
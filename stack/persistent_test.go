@@ -0,0 +1,47 @@
+package stack_test
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/igorroncevic/go-utils/stack"
+)
+
+func TestPersistentStack(t *testing.T) {
+	empty := stack.NewPersistent[int]()
+	v1 := empty.Push(1)
+	v2 := v1.Push(2)
+	v3 := v2.Push(3)
+
+	// Older versions are untouched
+	assert.True(t, empty.IsEmpty())
+	assert.Equal(t, 1, v1.Size())
+	assert.Equal(t, 3, v3.Size())
+
+	top, popped, err := v3.Pop()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, *top)
+	assert.Equal(t, 2, popped.Size())
+
+	// Branching off an older version
+	branch := popped.Push(42)
+
+	peeked, err := branch.Peek()
+	assert.NoError(t, err)
+	assert.Equal(t, 42, *peeked)
+
+	peeked, err = v3.Peek()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, *peeked)
+
+	values := []int{}
+	branch.Each(func(val int) {
+		values = append(values, val)
+	})
+
+	assert.Equal(t, []int{42, 2, 1}, values)
+
+	_, same, err := empty.Pop()
+	assert.Error(t, err)
+	assert.True(t, same == empty)
+}
@@ -0,0 +1,76 @@
+package stack
+
+import "sync"
+
+// SyncStack is a stack that is safe for concurrent use, guarded by a mutex.
+type SyncStack[T any] struct {
+	mu    sync.Mutex
+	stack *Stack[T]
+}
+
+func NewSync[T any]() *SyncStack[T] {
+	return &SyncStack[T]{
+		stack: New[T](),
+	}
+}
+
+// IsEmpty: check if stack is empty
+func (s *SyncStack[T]) IsEmpty() bool {
+	return s.Size() == 0
+}
+
+// Push a new value onto the stack
+func (s *SyncStack[T]) Push(val T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stack.Push(val)
+}
+
+// Remove and return top element of stack.
+func (s *SyncStack[T]) Pop() (*T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.stack.Pop()
+}
+
+// Peek returns a copy of the stack's top element but does not remove it.
+func (s *SyncStack[T]) Peek() (*T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	top, err := s.stack.Peek()
+	if err != nil {
+		return nil, err
+	}
+
+	// Pointer to the element itself would escape the lock
+	element := *top
+
+	return &element, nil
+}
+
+func (s *SyncStack[T]) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.stack.String()
+}
+
+func (s *SyncStack[T]) Size() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.stack.Size()
+}
+
+// Copy returns a copy of this stack.
+func (s *SyncStack[T]) Copy() *SyncStack[T] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return &SyncStack[T]{
+		stack: s.stack.Copy(),
+	}
+}
@@ -0,0 +1,146 @@
+package stack_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/igorroncevic/go-utils/stack"
+)
+
+// concurrentStack is the API shared by the stacks that are safe for concurrent use.
+type concurrentStack interface {
+	Push(val int)
+	Pop() (*int, error)
+	Peek() (*int, error)
+	Size() int
+	IsEmpty() bool
+}
+
+const (
+	goroutines = 8
+	perRoutine = 1000
+)
+
+// checkConcurrent pushes and pops from multiple goroutines at once, and checks that
+// every pushed value is popped exactly once.
+func checkConcurrent(t *testing.T, st concurrentStack) {
+	var wg sync.WaitGroup
+
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+
+		go func(g int) {
+			defer wg.Done()
+
+			for i := 0; i < perRoutine; i++ {
+				st.Push(g*perRoutine + i)
+			}
+		}(g)
+	}
+
+	wg.Wait()
+	assert.Equal(t, goroutines*perRoutine, st.Size(), "unexpected stack size after pushes")
+
+	var (
+		mu     sync.Mutex
+		popped = make(map[int]int)
+	)
+
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := 0; i < perRoutine; i++ {
+				val, err := st.Pop()
+				if err != nil {
+					t.Error(err)
+					return
+				}
+
+				mu.Lock()
+				popped[*val]++
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, goroutines*perRoutine, len(popped), "unexpected number of popped values")
+
+	for val, count := range popped {
+		assert.Equal(t, 1, count, "value %d popped more than once", val)
+	}
+
+	assert.True(t, st.IsEmpty(), "stack is not empty")
+
+	_, err := st.Peek()
+	assert.Error(t, err)
+}
+
+func TestSyncStack(t *testing.T) {
+	st := stack.NewSync[int]()
+	st.Push(1)
+	st.Push(2)
+
+	top, err := st.Peek()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, *top)
+
+	cpy := st.Copy()
+	_, err = st.Pop()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, cpy.Size(), "copy was affected by pop")
+
+	_, err = st.Pop()
+	assert.NoError(t, err)
+
+	checkConcurrent(t, st)
+}
+
+func TestTreiberStack(t *testing.T) {
+	st := stack.NewTreiber[int]()
+	st.Push(1)
+	st.Push(2)
+
+	top, err := st.Peek()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, *top)
+
+	cpy := st.Copy()
+	_, err = st.Pop()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, cpy.Size(), "copy was affected by pop")
+
+	top, err = cpy.Pop()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, *top)
+
+	_, err = st.Pop()
+	assert.NoError(t, err)
+
+	checkConcurrent(t, st)
+}
+
+func benchmarkContention(b *testing.B, st concurrentStack) {
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			if i%2 == 0 {
+				st.Push(i)
+			} else {
+				_, _ = st.Pop()
+			}
+		}
+	})
+}
+
+func BenchmarkSyncStackContention(b *testing.B) {
+	benchmarkContention(b, stack.NewSync[int]())
+}
+
+func BenchmarkTreiberStackContention(b *testing.B) {
+	benchmarkContention(b, stack.NewTreiber[int]())
+}
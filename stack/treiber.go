@@ -0,0 +1,101 @@
+package stack
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/igorroncevic/go-utils/util"
+)
+
+// TreiberStack is a lock-free stack that is safe for concurrent use. Pushes and pops
+// retry an atomic compare-and-swap of the top node instead of waiting on a lock,
+// which keeps it fast under high contention.
+type TreiberStack[T any] struct {
+	head atomic.Pointer[treiberNode[T]]
+	size atomic.Int64
+}
+
+// treiberNode is never modified after it is pushed, which is what makes sharing it safe.
+type treiberNode[T any] struct {
+	value T
+	next  *treiberNode[T]
+}
+
+func NewTreiber[T any]() *TreiberStack[T] {
+	return &TreiberStack[T]{}
+}
+
+// IsEmpty: check if stack is empty
+func (s *TreiberStack[T]) IsEmpty() bool {
+	return s.head.Load() == nil
+}
+
+// Push a new value onto the stack
+func (s *TreiberStack[T]) Push(val T) {
+	node := &treiberNode[T]{value: val}
+
+	for {
+		node.next = s.head.Load()
+
+		if s.head.CompareAndSwap(node.next, node) {
+			s.size.Add(1)
+			return
+		}
+	}
+}
+
+// Remove and return top element of stack.
+func (s *TreiberStack[T]) Pop() (*T, error) {
+	for {
+		top := s.head.Load()
+		if top == nil {
+			return nil, fmt.Errorf("stack is empty")
+		}
+
+		if s.head.CompareAndSwap(top, top.next) {
+			s.size.Add(-1)
+
+			element := top.value
+
+			return &element, nil
+		}
+	}
+}
+
+// Peek returns a copy of the stack's top element but does not remove it.
+func (s *TreiberStack[T]) Peek() (*T, error) {
+	top := s.head.Load()
+	if top == nil {
+		return nil, fmt.Errorf("stack is empty")
+	}
+
+	element := top.value
+
+	return &element, nil
+}
+
+// Size returns the number of elements in the stack. Under concurrent use, it is only a
+// snapshot that may already be outdated by the time it is returned.
+func (s *TreiberStack[T]) Size() int {
+	// The counter is updated after the swap, so a pop can bring it below zero before
+	// the matching push has counted its element
+	return int(util.Max(s.size.Load(), 0))
+}
+
+// Copy returns a copy of this stack in O(n), which shares the nodes with the original.
+func (s *TreiberStack[T]) Copy() *TreiberStack[T] {
+	var (
+		copy = NewTreiber[T]()
+		top  = s.head.Load()
+		size int64
+	)
+
+	for node := top; node != nil; node = node.next {
+		size++
+	}
+
+	copy.head.Store(top)
+	copy.size.Store(size)
+
+	return copy
+}
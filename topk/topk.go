@@ -0,0 +1,154 @@
+// Package topk implements the Space-Saving algorithm, which tracks the most frequent keys of
+// a stream using a fixed number of counters. See "Efficient Computation of Frequent and Top-k
+// Elements in Data Streams" by Metwally et al.
+package topk
+
+import (
+	"container/heap"
+	"sort"
+
+	"github.com/igorroncevic/go-utils/hashmap"
+	"github.com/igorroncevic/go-utils/util"
+)
+
+// Item is a tracked key with its estimated count. The estimate never undercounts,
+// and overcounts by at most Error.
+type Item[T any] struct {
+	Key   T
+	Count uint64
+	Error uint64
+}
+
+// Tracker tracks up to k keys. Once all of its counters are taken, a new key replaces the one with the
+// lowest count and inherits that count as its error. Any key that occurred more than Total() / k times
+// is guaranteed to be tracked.
+type Tracker[T any] struct {
+	capacity int
+	total    uint64
+	// leaders is a min-heap of the tracked items by their count, so the one to replace is at the top.
+	leaders leaders[T]
+	index   *hashmap.Map[T, *entry[T]]
+}
+
+type entry[T any] struct {
+	Item[T]
+	// position is the index of the entry in the heap.
+	position int
+}
+
+// New constructs a new tracker with 'k' counters, which uses 'equals' and 'hash' to tell keys apart.
+func New[T any](k int, equals util.EqualsFn[T], hash util.HashFn[T]) *Tracker[T] {
+	k = util.Max(k, 1)
+
+	return &Tracker[T]{
+		capacity: k,
+		leaders:  make(leaders[T], 0, k),
+		index:    hashmap.New[T, *entry[T]](uint64(k), equals, hash),
+	}
+}
+
+// Add adds 'n' occurrences of the key.
+func (t *Tracker[T]) Add(key T, n uint64) {
+	t.total += n
+
+	if e, ok := t.index.Get(key); ok {
+		e.Count += n
+		heap.Fix(&t.leaders, e.position)
+
+		return
+	}
+
+	if len(t.leaders) < t.capacity {
+		e := &entry[T]{Item: Item[T]{Key: key, Count: n}}
+		heap.Push(&t.leaders, e)
+		t.index.Put(key, e)
+
+		return
+	}
+
+	// Replace the least frequent key, which may have occurred up to its count times before
+	e := t.leaders[0]
+	t.index.Remove(e.Key)
+
+	e.Key, e.Error = key, e.Count
+	e.Count += n
+
+	heap.Fix(&t.leaders, 0)
+	t.index.Put(key, e)
+}
+
+// Get returns the key with its estimated count, or false if it is not tracked.
+func (t *Tracker[T]) Get(key T) (Item[T], bool) {
+	if e, ok := t.index.Get(key); ok {
+		return e.Item, true
+	}
+
+	return Item[T]{}, false
+}
+
+// Top returns up to 'n' of the most frequent keys, ordered from the most frequent one.
+func (t *Tracker[T]) Top(n int) []Item[T] {
+	items := make([]Item[T], len(t.leaders))
+	for i, e := range t.leaders {
+		items[i] = e.Item
+	}
+
+	// Ties are broken by the error, since a lower error means the count is more certain
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Count != items[j].Count {
+			return items[i].Count > items[j].Count
+		}
+
+		return items[i].Error < items[j].Error
+	})
+
+	return items[:util.Clamp(n, 0, len(items))]
+}
+
+// Size returns the number of tracked keys.
+func (t *Tracker[T]) Size() int {
+	return len(t.leaders)
+}
+
+// Total returns the number of occurrences added to the tracker.
+func (t *Tracker[T]) Total() uint64 {
+	return t.total
+}
+
+// Clear removes all keys from the tracker.
+func (t *Tracker[T]) Clear() {
+	t.leaders = make(leaders[T], 0, t.capacity)
+	t.index.Clear()
+	t.total = 0
+}
+
+// leaders implements heap.Interface.
+type leaders[T any] []*entry[T]
+
+func (l leaders[T]) Len() int {
+	return len(l)
+}
+
+func (l leaders[T]) Less(i, j int) bool {
+	return l[i].Count < l[j].Count
+}
+
+func (l leaders[T]) Swap(i, j int) {
+	l[i], l[j] = l[j], l[i]
+	l[i].position = i
+	l[j].position = j
+}
+
+func (l *leaders[T]) Push(x any) {
+	e := x.(*entry[T])
+	e.position = len(*l)
+	*l = append(*l, e)
+}
+
+func (l *leaders[T]) Pop() any {
+	old := *l
+	e := old[len(old)-1]
+	*l = old[:len(old)-1]
+
+	return e
+}
@@ -0,0 +1,72 @@
+package topk_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/igorroncevic/go-utils/topk"
+	"github.com/igorroncevic/go-utils/util"
+)
+
+func TestTracker(t *testing.T) {
+	var (
+		r       = rand.New(rand.NewSource(1)) //nolint:gosec // deterministic test data
+		zipf    = rand.NewZipf(r, 1.5, 1, 100000)
+		tracker = topk.New(100, util.Equals[uint64], util.HashUint64)
+		actual  = map[uint64]uint64{}
+	)
+
+	for i := 0; i < 100000; i++ {
+		key := zipf.Uint64()
+
+		tracker.Add(key, 1)
+		actual[key]++
+	}
+
+	keys := make([]uint64, 0, len(actual))
+	for key := range actual {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool { return actual[keys[i]] > actual[keys[j]] })
+
+	top := tracker.Top(10)
+	assert.Equal(t, 10, len(top))
+
+	for i, item := range top {
+		assert.Equal(t, keys[i], item.Key)
+		assert.True(t, item.Count >= actual[item.Key])
+		assert.True(t, item.Count-item.Error <= actual[item.Key])
+	}
+
+	assert.Equal(t, 100, tracker.Size())
+	assert.Equal(t, uint64(100000), tracker.Total())
+	assert.Equal(t, 100, len(tracker.Top(1000)))
+}
+
+func TestTrackerEviction(t *testing.T) {
+	tracker := topk.New(2, util.Equals[string], util.HashString)
+
+	tracker.Add("a", 5)
+	tracker.Add("b", 3)
+	tracker.Add("c", 1)
+
+	// "c" replaced "b" and inherited its count as the error
+	_, ok := tracker.Get("b")
+	assert.False(t, ok)
+
+	item, ok := tracker.Get("c")
+	assert.True(t, ok)
+	assert.Equal(t, topk.Item[string]{Key: "c", Count: 4, Error: 3}, item)
+
+	assert.Equal(t, []topk.Item[string]{
+		{Key: "a", Count: 5},
+		{Key: "c", Count: 4, Error: 3},
+	}, tracker.Top(5))
+
+	tracker.Clear()
+	assert.Equal(t, 0, tracker.Size())
+	assert.Equal(t, []topk.Item[string]{}, tracker.Top(5))
+}
@@ -0,0 +1,134 @@
+package treemap
+
+import (
+	"fmt"
+
+	"github.com/igorroncevic/go-utils/util"
+)
+
+// Set is a set that keeps its values sorted by the less func.
+type Set[T any] struct {
+	values *Map[T, struct{}]
+}
+
+// NewSet constructs a new, empty set ordered by the less func.
+func NewSet[T any](less util.LessFn[T]) *Set[T] {
+	return &Set[T]{
+		values: New[T, struct{}](less),
+	}
+}
+
+// Add adds the value to the set, or returns an error if it is already present.
+func (s *Set[T]) Add(val T) error {
+	if s.values.Contains(val) {
+		return fmt.Errorf("value '%v' already exists", val)
+	}
+
+	s.values.Put(val, struct{}{})
+
+	return nil
+}
+
+// Remove removes the value from the set, or returns an error if it is not present.
+func (s *Set[T]) Remove(val T) error {
+	if !s.values.Contains(val) {
+		return fmt.Errorf("value '%v' does not exist", val)
+	}
+
+	s.values.Remove(val)
+
+	return nil
+}
+
+// Contains returns whether the value is present in the set.
+func (s *Set[T]) Contains(val T) bool {
+	return s.values.Contains(val)
+}
+
+// Min returns the smallest value, or false if the set is empty.
+func (s *Set[T]) Min() (T, bool) {
+	return value(s.values.Min())
+}
+
+// Max returns the largest value, or false if the set is empty.
+func (s *Set[T]) Max() (T, bool) {
+	return value(s.values.Max())
+}
+
+// Floor returns the largest value less than or equal to the given one.
+func (s *Set[T]) Floor(val T) (T, bool) {
+	return value(s.values.Floor(val))
+}
+
+// Ceiling returns the smallest value greater than or equal to the given one.
+func (s *Set[T]) Ceiling(val T) (T, bool) {
+	return value(s.values.Ceiling(val))
+}
+
+// Lower returns the largest value strictly less than the given one.
+func (s *Set[T]) Lower(val T) (T, bool) {
+	return value(s.values.Lower(val))
+}
+
+// Higher returns the smallest value strictly greater than the given one.
+func (s *Set[T]) Higher(val T) (T, bool) {
+	return value(s.values.Higher(val))
+}
+
+// Each calls 'fn' on every value in ascending order.
+// Iteration stops as soon as 'fn' returns true.
+func (s *Set[T]) Each(fn func(val T) bool) {
+	s.values.Each(func(val T, _ struct{}) bool {
+		return fn(val)
+	})
+}
+
+// EachReverse calls 'fn' on every value in descending order.
+// Iteration stops as soon as 'fn' returns true.
+func (s *Set[T]) EachReverse(fn func(val T) bool) {
+	s.values.EachReverse(func(val T, _ struct{}) bool {
+		return fn(val)
+	})
+}
+
+// Range calls 'fn' on every value where from <= val < to, in ascending order.
+// Iteration stops as soon as 'fn' returns true.
+func (s *Set[T]) Range(from, to T, fn func(val T) bool) {
+	s.values.Range(from, to, func(val T, _ struct{}) bool {
+		return fn(val)
+	})
+}
+
+// RangeReverse calls 'fn' on every value where from <= val < to, in descending order.
+// Iteration stops as soon as 'fn' returns true.
+func (s *Set[T]) RangeReverse(from, to T, fn func(val T) bool) {
+	s.values.RangeReverse(from, to, func(val T, _ struct{}) bool {
+		return fn(val)
+	})
+}
+
+// Size returns the number of values in the set.
+func (s *Set[T]) Size() int {
+	return s.values.Size()
+}
+
+// Clear removes all values from the set.
+func (s *Set[T]) Clear() {
+	s.values.Clear()
+}
+
+// ToSlice returns the values of the set in ascending order.
+func (s *Set[T]) ToSlice() []T {
+	sliced := make([]T, 0, s.Size())
+
+	s.Each(func(val T) bool {
+		sliced = append(sliced, val)
+		return false
+	})
+
+	return sliced
+}
+
+func value[T any](val T, _ struct{}, ok bool) (T, bool) {
+	return val, ok
+}
@@ -0,0 +1,413 @@
+// Package treemap implements ordered containers on top of a left-leaning red-black tree,
+// which keeps Get, Put and Remove at O(log n) in the worst case.
+package treemap
+
+import "github.com/igorroncevic/go-utils/util"
+
+const (
+	red   = true
+	black = false
+)
+
+// Map is a map that keeps its keys sorted by the less func.
+type Map[K, V any] struct {
+	root   *node[K, V]
+	length int
+
+	less util.LessFn[K]
+}
+
+type node[K, V any] struct {
+	key         K
+	value       V
+	left, right *node[K, V]
+	color       bool
+}
+
+// New constructs a new, empty map ordered by the less func.
+func New[K, V any](less util.LessFn[K]) *Map[K, V] {
+	return &Map[K, V]{
+		less: less,
+	}
+}
+
+// Get returns the value stored for this key, or false if there is no such value.
+func (m *Map[K, V]) Get(key K) (V, bool) {
+	if n := m.find(key); n != nil {
+		return n.value, true
+	}
+
+	var empty V
+
+	return empty, false
+}
+
+// Contains returns whether the key is present in the map.
+func (m *Map[K, V]) Contains(key K) bool {
+	return m.find(key) != nil
+}
+
+// Put maps the given key to the given value. If the key already exists its
+// value will be overwritten with the new value.
+func (m *Map[K, V]) Put(key K, val V) {
+	m.root = m.put(m.root, key, val)
+	m.root.color = black
+}
+
+// Remove removes the specified key-value pair from the map.
+func (m *Map[K, V]) Remove(key K) {
+	if !m.Contains(key) {
+		return
+	}
+
+	// Root is temporarily made red so that the red link can be pushed down the tree
+	if !isRed(m.root.left) && !isRed(m.root.right) {
+		m.root.color = red
+	}
+
+	m.root = m.remove(m.root, key)
+	if m.root != nil {
+		m.root.color = black
+	}
+
+	m.length--
+}
+
+// Min returns the smallest key with its value, or false if the map is empty.
+func (m *Map[K, V]) Min() (K, V, bool) {
+	n := m.root
+	for n != nil && n.left != nil {
+		n = n.left
+	}
+
+	return entry(n)
+}
+
+// Max returns the largest key with its value, or false if the map is empty.
+func (m *Map[K, V]) Max() (K, V, bool) {
+	n := m.root
+	for n != nil && n.right != nil {
+		n = n.right
+	}
+
+	return entry(n)
+}
+
+// Floor returns the largest key less than or equal to the given key.
+func (m *Map[K, V]) Floor(key K) (K, V, bool) {
+	var found *node[K, V]
+
+	for n := m.root; n != nil; {
+		if m.less(key, n.key) {
+			n = n.left
+		} else {
+			found = n
+			n = n.right
+		}
+	}
+
+	return entry(found)
+}
+
+// Ceiling returns the smallest key greater than or equal to the given key.
+func (m *Map[K, V]) Ceiling(key K) (K, V, bool) {
+	var found *node[K, V]
+
+	for n := m.root; n != nil; {
+		if m.less(n.key, key) {
+			n = n.right
+		} else {
+			found = n
+			n = n.left
+		}
+	}
+
+	return entry(found)
+}
+
+// Lower returns the largest key strictly less than the given key.
+func (m *Map[K, V]) Lower(key K) (K, V, bool) {
+	var found *node[K, V]
+
+	for n := m.root; n != nil; {
+		if m.less(n.key, key) {
+			found = n
+			n = n.right
+		} else {
+			n = n.left
+		}
+	}
+
+	return entry(found)
+}
+
+// Higher returns the smallest key strictly greater than the given key.
+func (m *Map[K, V]) Higher(key K) (K, V, bool) {
+	var found *node[K, V]
+
+	for n := m.root; n != nil; {
+		if m.less(key, n.key) {
+			found = n
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+
+	return entry(found)
+}
+
+// Each calls 'fn' on every key-value pair in ascending order.
+// Iteration stops as soon as 'fn' returns true.
+func (m *Map[K, V]) Each(fn func(key K, val V) bool) {
+	m.ascend(m.root, nil, nil, fn)
+}
+
+// EachReverse calls 'fn' on every key-value pair in descending order.
+// Iteration stops as soon as 'fn' returns true.
+func (m *Map[K, V]) EachReverse(fn func(key K, val V) bool) {
+	m.descend(m.root, nil, nil, fn)
+}
+
+// Range calls 'fn' on every key-value pair where from <= key < to, in ascending order.
+// Iteration stops as soon as 'fn' returns true.
+func (m *Map[K, V]) Range(from, to K, fn func(key K, val V) bool) {
+	m.ascend(m.root, &from, &to, fn)
+}
+
+// RangeReverse calls 'fn' on every key-value pair where from <= key < to, in descending order.
+// Iteration stops as soon as 'fn' returns true.
+func (m *Map[K, V]) RangeReverse(from, to K, fn func(key K, val V) bool) {
+	m.descend(m.root, &from, &to, fn)
+}
+
+// Size returns the number of items in the map.
+func (m *Map[K, V]) Size() int {
+	return m.length
+}
+
+// Clear removes all key-value pairs from the map.
+func (m *Map[K, V]) Clear() {
+	m.root = nil
+	m.length = 0
+}
+
+func (m *Map[K, V]) find(key K) *node[K, V] {
+	n := m.root
+
+	for n != nil {
+		switch util.Compare(key, n.key, m.less) {
+		case -1:
+			n = n.left
+		case 1:
+			n = n.right
+		default:
+			return n
+		}
+	}
+
+	return nil
+}
+
+func (m *Map[K, V]) put(h *node[K, V], key K, val V) *node[K, V] {
+	if h == nil {
+		m.length++
+		return &node[K, V]{key: key, value: val, color: red}
+	}
+
+	switch util.Compare(key, h.key, m.less) {
+	case -1:
+		h.left = m.put(h.left, key, val)
+	case 1:
+		h.right = m.put(h.right, key, val)
+	default:
+		h.value = val
+	}
+
+	return balance(h)
+}
+
+// remove deletes the key from the subtree, which must contain it.
+func (m *Map[K, V]) remove(h *node[K, V], key K) *node[K, V] {
+	if m.less(key, h.key) {
+		if !isRed(h.left) && !isRed(h.left.left) {
+			h = moveRedLeft(h)
+		}
+
+		h.left = m.remove(h.left, key)
+
+		return balance(h)
+	}
+
+	if isRed(h.left) {
+		h = rotateRight(h)
+	}
+
+	if !m.less(h.key, key) && h.right == nil {
+		return nil
+	}
+
+	if !isRed(h.right) && !isRed(h.right.left) {
+		h = moveRedRight(h)
+	}
+
+	if !m.less(h.key, key) {
+		// Replace this node with its successor, then remove the successor instead
+		successor := minNode(h.right)
+		h.key, h.value = successor.key, successor.value
+		h.right = removeMin(h.right)
+	} else {
+		h.right = m.remove(h.right, key)
+	}
+
+	return balance(h)
+}
+
+// ascend walks the subtree in order, skipping keys outside of [from, to) when bounds are set.
+// It returns true if the iteration was stopped.
+func (m *Map[K, V]) ascend(h *node[K, V], from, to *K, fn func(key K, val V) bool) bool {
+	if h == nil {
+		return false
+	}
+
+	aboveFrom := from == nil || !m.less(h.key, *from)
+	belowTo := to == nil || m.less(h.key, *to)
+
+	if aboveFrom && m.ascend(h.left, from, to, fn) {
+		return true
+	}
+
+	if aboveFrom && belowTo && fn(h.key, h.value) {
+		return true
+	}
+
+	return belowTo && m.ascend(h.right, from, to, fn)
+}
+
+// descend is the mirror image of ascend.
+func (m *Map[K, V]) descend(h *node[K, V], from, to *K, fn func(key K, val V) bool) bool {
+	if h == nil {
+		return false
+	}
+
+	aboveFrom := from == nil || !m.less(h.key, *from)
+	belowTo := to == nil || m.less(h.key, *to)
+
+	if belowTo && m.descend(h.right, from, to, fn) {
+		return true
+	}
+
+	if aboveFrom && belowTo && fn(h.key, h.value) {
+		return true
+	}
+
+	return aboveFrom && m.descend(h.left, from, to, fn)
+}
+
+func isRed[K, V any](n *node[K, V]) bool {
+	return n != nil && n.color == red
+}
+
+func rotateLeft[K, V any](h *node[K, V]) *node[K, V] {
+	x := h.right
+	h.right = x.left
+	x.left = h
+	x.color = h.color
+	h.color = red
+
+	return x
+}
+
+func rotateRight[K, V any](h *node[K, V]) *node[K, V] {
+	x := h.left
+	h.left = x.right
+	x.right = h
+	x.color = h.color
+	h.color = red
+
+	return x
+}
+
+func flipColors[K, V any](h *node[K, V]) {
+	h.color = !h.color
+	h.left.color = !h.left.color
+	h.right.color = !h.right.color
+}
+
+// moveRedLeft makes h.left or one of its children red, assuming that h is red and both h.left and h.left.left are black.
+func moveRedLeft[K, V any](h *node[K, V]) *node[K, V] {
+	flipColors(h)
+
+	if isRed(h.right.left) {
+		h.right = rotateRight(h.right)
+		h = rotateLeft(h)
+		flipColors(h)
+	}
+
+	return h
+}
+
+// moveRedRight makes h.right or one of its children red, assuming that h is red and both h.right and h.right.left are black.
+func moveRedRight[K, V any](h *node[K, V]) *node[K, V] {
+	flipColors(h)
+
+	if isRed(h.left.left) {
+		h = rotateRight(h)
+		flipColors(h)
+	}
+
+	return h
+}
+
+// balance restores the left-leaning red-black invariants on the way up the tree.
+func balance[K, V any](h *node[K, V]) *node[K, V] {
+	if isRed(h.right) && !isRed(h.left) {
+		h = rotateLeft(h)
+	}
+
+	if isRed(h.left) && isRed(h.left.left) {
+		h = rotateRight(h)
+	}
+
+	if isRed(h.left) && isRed(h.right) {
+		flipColors(h)
+	}
+
+	return h
+}
+
+func removeMin[K, V any](h *node[K, V]) *node[K, V] {
+	if h.left == nil {
+		return nil
+	}
+
+	if !isRed(h.left) && !isRed(h.left.left) {
+		h = moveRedLeft(h)
+	}
+
+	h.left = removeMin(h.left)
+
+	return balance(h)
+}
+
+func minNode[K, V any](h *node[K, V]) *node[K, V] {
+	for h.left != nil {
+		h = h.left
+	}
+
+	return h
+}
+
+// entry unpacks the node, returning false if there is none.
+func entry[K, V any](n *node[K, V]) (K, V, bool) {
+	if n == nil {
+		var (
+			key K
+			val V
+		)
+
+		return key, val, false
+	}
+
+	return n.key, n.value, true
+}
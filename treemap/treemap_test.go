@@ -0,0 +1,142 @@
+package treemap_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/igorroncevic/go-utils/treemap"
+	"github.com/igorroncevic/go-utils/util"
+)
+
+func collect(m *treemap.Map[int, int], each func(fn func(key, val int) bool)) []int {
+	keys := []int{}
+
+	each(func(key, val int) bool {
+		keys = append(keys, key)
+		return false
+	})
+
+	return keys
+}
+
+func TestTreeMapVsSortedSliceCrossCheck(t *testing.T) {
+	random := rand.New(rand.NewSource(42)) //nolint:gosec // deterministic test data
+	m := treemap.New[int, int](util.Less[int])
+	stdmap := make(map[int]int)
+
+	for i := 0; i < 5000; i++ {
+		key := random.Intn(1000)
+
+		if random.Intn(3) == 0 {
+			delete(stdmap, key)
+			m.Remove(key)
+		} else {
+			stdmap[key] = i
+			m.Put(key, i)
+		}
+	}
+
+	keys := make([]int, 0, len(stdmap))
+	for key := range stdmap {
+		keys = append(keys, key)
+	}
+
+	sort.Ints(keys)
+
+	assert.Equal(t, len(keys), m.Size(), "unexpected map size")
+	assert.Equal(t, keys, collect(m, m.Each), "unexpected ascending order")
+
+	for key, val := range stdmap {
+		v, ok := m.Get(key)
+		assert.True(t, ok, "key %d not found", key)
+		assert.Equal(t, val, v)
+	}
+
+	reversed := collect(m, m.EachReverse)
+	for i := range keys {
+		assert.Equal(t, keys[len(keys)-1-i], reversed[i], "unexpected descending order")
+	}
+}
+
+func TestTreeMapNavigation(t *testing.T) {
+	m := treemap.New[int, string](util.Less[int])
+
+	_, _, ok := m.Min()
+	assert.False(t, ok)
+
+	for _, key := range []int{10, 20, 30, 40} {
+		m.Put(key, "")
+	}
+
+	key, _, _ := m.Min()
+	assert.Equal(t, 10, key)
+
+	key, _, _ = m.Max()
+	assert.Equal(t, 40, key)
+
+	key, _, _ = m.Floor(25)
+	assert.Equal(t, 20, key)
+
+	key, _, _ = m.Floor(20)
+	assert.Equal(t, 20, key)
+
+	_, _, ok = m.Floor(5)
+	assert.False(t, ok)
+
+	key, _, _ = m.Ceiling(25)
+	assert.Equal(t, 30, key)
+
+	key, _, _ = m.Lower(20)
+	assert.Equal(t, 10, key)
+
+	key, _, _ = m.Higher(20)
+	assert.Equal(t, 30, key)
+
+	_, _, ok = m.Higher(40)
+	assert.False(t, ok)
+}
+
+func TestTreeMapRange(t *testing.T) {
+	m := treemap.New[int, int](util.Less[int])
+	for i := 0; i < 10; i++ {
+		m.Put(i, i)
+	}
+
+	assert.Equal(t, []int{3, 4, 5, 6}, collect(m, func(fn func(key, val int) bool) { m.Range(3, 7, fn) }))
+	assert.Equal(t, []int{6, 5, 4, 3}, collect(m, func(fn func(key, val int) bool) { m.RangeReverse(3, 7, fn) }))
+
+	// Iteration stops early
+	visited := 0
+
+	m.Range(0, 10, func(key, val int) bool {
+		visited++
+		return key == 4
+	})
+
+	assert.Equal(t, 5, visited)
+}
+
+func TestTreeSet(t *testing.T) {
+	s := treemap.NewSet[string](util.Less[string])
+
+	assert.NoError(t, s.Add("banana"))
+	assert.NoError(t, s.Add("apple"))
+	assert.NoError(t, s.Add("cherry"))
+	assert.Error(t, s.Add("apple"))
+
+	assert.Equal(t, []string{"apple", "banana", "cherry"}, s.ToSlice())
+
+	val, ok := s.Ceiling("b")
+	assert.True(t, ok)
+	assert.Equal(t, "banana", val)
+
+	val, _ = s.Max()
+	assert.Equal(t, "cherry", val)
+
+	assert.NoError(t, s.Remove("banana"))
+	assert.Error(t, s.Remove("banana"))
+	assert.False(t, s.Contains("banana"))
+	assert.Equal(t, 2, s.Size())
+}